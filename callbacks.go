@@ -0,0 +1,46 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hdf5
+
+// This file holds the Go functions called back from HDF5.
+// As it uses //export, its preamble must only contain declarations.
+
+// #include "hdf5.h"
+import "C"
+
+import (
+	"runtime/cgo"
+	"unsafe"
+)
+
+// handleValue returns the value of the cgo.Handle passed as opaque callback
+// data, since Go pointers can not be retained by C code.
+func handleValue(data unsafe.Pointer) interface{} {
+	return cgo.Handle(uintptr(data)).Value()
+}
+
+//export _go_hdf5_ovisit_cb
+func _go_hdf5_ovisit_cb(id C.hid_t, name *C.char, info *C.H5O_info_t, data unsafe.Pointer) C.herr_t {
+	w := handleValue(data).(*walker)
+	return w.visit(C.GoString(name), newObjectInfo(info))
+}
+
+//export _go_hdf5_aiterate_cb
+func _go_hdf5_aiterate_cb(id C.hid_t, name *C.char, info *C.H5A_info_t, data unsafe.Pointer) C.herr_t {
+	it := handleValue(data).(*attrIterator)
+	return it.visit(C.GoString(name), newAttributeInfo(info))
+}
+
+//export _go_hdf5_ewalk_cb
+func _go_hdf5_ewalk_cb(n C.unsigned, desc *C.H5E_error2_t, data unsafe.Pointer) C.herr_t {
+	appendErrorFrame(data, desc)
+	return 0
+}
+
+//export _go_hdf5_dsiterate_cb
+func _go_hdf5_dsiterate_cb(did C.hid_t, dim C.unsigned, scale C.hid_t, data unsafe.Pointer) C.herr_t {
+	l := handleValue(data).(*scaleLister)
+	return l.visit(scale)
+}
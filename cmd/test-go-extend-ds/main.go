@@ -13,22 +13,78 @@ import (
 func main() {
 
 	fname := "SDSextendible.h5"
-	//dsname:= "ExtendibleArray"
-	//NX := 10
-	//NY :=  5
-	//RANK:= 2
+	dsname := "ExtendibleArray"
+	const (
+		NX = 10
+		NY = 3
+	)
 
-	//dims := []int{3, 3} // dset dimensions at creation
-	//maxdims:= []int{hdf5.S_UNLIMITED, hdf5.S_UNLIMITED}
-
-	//mspace := hdf5.CreateDataspace(dims, maxdims)
+	dims := []uint{3, NY} // dset dimensions at creation
+	maxdims := []uint{hdf5.S_UNLIMITED, NY}
 
 	// create a new file
 	f, err := hdf5.CreateFile(fname, hdf5.F_ACC_TRUNC)
 	if err != nil {
 		panic(err)
 	}
+	defer f.Close()
 
 	fmt.Printf(":: file [%s] created\n", f.Name())
 
+	mspace, err := hdf5.CreateSimpleDataspace(dims, maxdims)
+	if err != nil {
+		panic(err)
+	}
+	defer mspace.Close()
+
+	// unlimited dimensions require chunking
+	dcpl, err := hdf5.NewPropList(hdf5.P_DATASET_CREATE)
+	if err != nil {
+		panic(err)
+	}
+	defer dcpl.Close()
+	err = dcpl.SetChunk([]uint{2, NY})
+	if err != nil {
+		panic(err)
+	}
+
+	dset, err := f.CreateDatasetWith(dsname, hdf5.T_NATIVE_INT, mspace, dcpl)
+	if err != nil {
+		panic(err)
+	}
+	defer dset.Close()
+
+	data := [3][NY]int32{}
+	for i := range data {
+		for j := range data[i] {
+			data[i][j] = 1
+		}
+	}
+	err = dset.Write(&data)
+	if err != nil {
+		panic(err)
+	}
+
+	// extend the dataset up to NX rows
+	ext := make([][NY]int32, NX-len(data))
+	for i := range ext {
+		for j := range ext[i] {
+			ext[i][j] = 2
+		}
+	}
+	err = dset.Append(ext)
+	if err != nil {
+		panic(err)
+	}
+
+	all := [NX][NY]int32{}
+	err = dset.Read(&all)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf(":: dataset [%s] extended to %d rows\n", dset.Name(), len(all))
+	for _, row := range all {
+		fmt.Printf("%v\n", row)
+	}
 }
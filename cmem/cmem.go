@@ -11,3 +11,10 @@ package cmem
 type CMarshaler interface {
 	MarshalC() ([]byte, error)
 }
+
+// CUnmarshaler is an interface for types that can unmarshal their data from
+// a C compatible binary layout. UnmarshalC decodes the value from the start
+// of raw and returns the number of bytes it consumed.
+type CUnmarshaler interface {
+	UnmarshalC(raw []byte) (int, error)
+}
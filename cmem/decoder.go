@@ -0,0 +1,223 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmem
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"unsafe"
+)
+
+const ptrSize = int(unsafe.Sizeof(uintptr(0)))
+
+// Decoder is a wrapper type for information necessary to decode in memory
+// objects read from e.g. a PacketTable into Go values. It is the inverse
+// of Encoder for values without nested slices.
+type Decoder struct {
+	// Buf contains the data to decode.
+	Buf    []byte
+	offset int
+}
+
+// Decode decodes the binary form stored in Buf into the value pointed to
+// by data, and advances to the next value of Buf. The buffer is a Go
+// representation of a C in-memory object, as read by HDF5 with a datatype
+// built from the Go type of data: struct fields are at the offsets of the
+// Go struct, strings are char* pointers and slices nested within values
+// are hvl_t variable-length sequences. Encoder produces the same layout for
+// values without nested slices.
+//
+// When data points to a slice, Decode decodes successive values into every
+// element of the slice. Decoded values do not reference the memory of Buf,
+// nor the C memory it points to.
+//
+// Struct values must only have exported fields, otherwise Decode will panic.
+func (dec *Decoder) Decode(data interface{}) error {
+	if data, ok := data.(CUnmarshaler); ok {
+		n, err := data.UnmarshalC(dec.Buf[dec.offset:])
+		if err != nil {
+			return err
+		}
+		dec.offset += n
+		return nil
+	}
+
+	rv := reflect.ValueOf(data)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("cmem: Decode expects a non-nil pointer, got %T", data)
+	}
+	rv = rv.Elem()
+
+	if rv.Kind() == reflect.Slice {
+		for i := 0; i < rv.Len(); i++ {
+			if err := dec.Decode(rv.Index(i).Addr().Interface()); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := dec.decode(rv, dec.offset); err != nil {
+		return err
+	}
+	dec.offset += Sizeof(rv.Type())
+	return nil
+}
+
+// Sizeof returns the size in bytes of the C representation of values of
+// type t decoded by Decoder: strings are stored as char* pointers, slices
+// as hvl_t variable-length sequences, and struct fields at the offsets of
+// the Go struct.
+func Sizeof(t reflect.Type) int {
+	switch t.Kind() {
+	case reflect.String:
+		return ptrSize
+	case reflect.Slice:
+		return 2 * ptrSize
+	case reflect.Array:
+		return t.Len() * Sizeof(t.Elem())
+	case reflect.Ptr:
+		return Sizeof(t.Elem())
+	default:
+		return int(t.Size())
+	}
+}
+
+// decode decodes the value at offset off of Buf into v.
+func (dec *Decoder) decode(v reflect.Value, off int) error {
+	if off > len(dec.Buf) {
+		return fmt.Errorf("cmem: buffer too short to decode %v at offset %d", v.Type(), off)
+	}
+	if v.CanAddr() {
+		if u, ok := v.Addr().Interface().(CUnmarshaler); ok {
+			_, err := u.UnmarshalC(dec.Buf[off:])
+			return err
+		}
+	}
+
+	// The trailing padding of structs may be missing from Buf, as Encoder
+	// does not write it, so only the size of leaf values is checked.
+	rt := v.Type()
+	if !isComposite(rt.Kind()) && off+Sizeof(rt) > len(dec.Buf) {
+		return fmt.Errorf("cmem: buffer too short to decode %v at offset %d", rt, off)
+	}
+	raw := dec.Buf[off:]
+
+	switch rt.Kind() {
+	case reflect.Array:
+		size := Sizeof(rt.Elem())
+		for i := 0; i < v.Len(); i++ {
+			if err := dec.decode(v.Index(i), off+i*size); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if err := dec.decode(v.Field(i), off+int(rt.Field(i).Offset)); err != nil {
+				return err
+			}
+		}
+
+	case reflect.String:
+		v.SetString(goString(readPointer(raw)))
+
+	case reflect.Slice:
+		// hvl_t variable-length sequence.
+		n := int(readSize(raw))
+		p := readPointer(raw[ptrSize:])
+		if n == 0 || p == nil {
+			v.Set(reflect.MakeSlice(rt, 0, 0))
+			return nil
+		}
+		size := Sizeof(rt.Elem())
+		seq := Decoder{Buf: unsafe.Slice((*byte)(p), n*size)}
+		s := reflect.MakeSlice(rt, n, n)
+		for i := 0; i < n; i++ {
+			if err := seq.decode(s.Index(i), i*size); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+
+	case reflect.Ptr:
+		if v.IsNil() {
+			v.Set(reflect.New(rt.Elem()))
+		}
+		return dec.decode(v.Elem(), off)
+
+	case reflect.Int8:
+		v.SetInt(int64(int8(raw[0])))
+
+	case reflect.Uint8:
+		v.SetUint(uint64(raw[0]))
+
+	case reflect.Int16:
+		v.SetInt(int64(int16(nativeEndian.Uint16(raw))))
+
+	case reflect.Uint16:
+		v.SetUint(uint64(nativeEndian.Uint16(raw)))
+
+	case reflect.Int32:
+		v.SetInt(int64(int32(nativeEndian.Uint32(raw))))
+
+	case reflect.Uint32:
+		v.SetUint(uint64(nativeEndian.Uint32(raw)))
+
+	case reflect.Int64:
+		v.SetInt(int64(nativeEndian.Uint64(raw)))
+
+	case reflect.Uint64:
+		v.SetUint(nativeEndian.Uint64(raw))
+
+	case reflect.Float32:
+		v.SetFloat(float64(math.Float32frombits(nativeEndian.Uint32(raw))))
+
+	case reflect.Float64:
+		v.SetFloat(math.Float64frombits(nativeEndian.Uint64(raw)))
+
+	case reflect.Bool:
+		v.SetBool(raw[0] != 0)
+
+	default:
+		return fmt.Errorf("cmem: Decode does not support datatype (%s)", rt.Kind())
+	}
+
+	return nil
+}
+
+// readPointer returns the pointer stored at the beginning of raw.
+func readPointer(raw []byte) unsafe.Pointer {
+	var p unsafe.Pointer
+	copy((*[ptrSize]byte)(unsafe.Pointer(&p))[:], raw)
+	return p
+}
+
+// readSize returns the size_t value stored at the beginning of raw.
+func readSize(raw []byte) uint64 {
+	if ptrSize == 4 {
+		return uint64(nativeEndian.Uint32(raw))
+	}
+	return nativeEndian.Uint64(raw)
+}
+
+// goString returns a copy of the null-terminated string at p.
+func goString(p unsafe.Pointer) string {
+	if p == nil {
+		return ""
+	}
+	n := 0
+	for *(*byte)(unsafe.Add(p, n)) != 0 {
+		n++
+	}
+	return string(unsafe.Slice((*byte)(p), n))
+}
+
+// isComposite returns whether values of kind k are decoded from their
+// elements, fields or pointee.
+func isComposite(k reflect.Kind) bool {
+	return k == reflect.Array || k == reflect.Struct || k == reflect.Ptr
+}
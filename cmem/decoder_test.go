@@ -0,0 +1,201 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cmem
+
+import (
+	"encoding/binary"
+	"fmt"
+	"reflect"
+	"strconv"
+	"testing"
+	"unsafe"
+)
+
+type point struct {
+	X, Y int16
+}
+
+// UnmarshalC decodes a point stored as two big-endian uint16 values.
+func (p *point) UnmarshalC(raw []byte) (int, error) {
+	if len(raw) < 4 {
+		return 0, fmt.Errorf("point: buffer too short")
+	}
+	p.X = int16(binary.BigEndian.Uint16(raw))
+	p.Y = int16(binary.BigEndian.Uint16(raw[2:]))
+	return 4, nil
+}
+
+func TestDecode(t *testing.T) {
+	type scalars struct {
+		V1 uint8
+		V2 uint64
+		V3 uint8
+		V4 uint16
+	}
+
+	for i, tc := range []struct {
+		buf  []byte
+		got  interface{}
+		want interface{}
+	}{
+		{
+			buf: []byte{
+				0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // |........|
+				0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // |........|
+				0x03, 0x00, 0x04, 0x00, 0x00, 0x00, 0x00, 0x00, // |........|
+			},
+			got:  new(scalars),
+			want: &scalars{1, 2, 3, 4},
+		},
+		{
+			buf: []byte{
+				0x01, 0x00, 0x00, 0x00, 0x14, 0x00, 0x00, 0x00, // |........|
+				0x2c, 0x01, 0x00, 0x00, 0xa0, 0x0f, 0x00, 0x00, // |,.......|
+			},
+			got:  &[]int32{0, 0, 0, 0},
+			want: &[]int32{1, 20, 300, 4000},
+		},
+		{
+			buf: []byte{
+				0x00, 0x00, 0x80, 0x3f, 0x01, 0x00, 0x00, 0x01, // |...?....|
+				0xff, 0xfe, 0x00, 0x00, /*                   */ // |....|
+			},
+			got: new(struct {
+				F float32
+				B [2]bool
+				P point
+			}),
+			want: &struct {
+				F float32
+				B [2]bool
+				P point
+			}{1, [2]bool{true, false}, point{1, -2}},
+		},
+	} {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			oldEndian := nativeEndian
+			nativeEndian = binary.LittleEndian
+			defer func() { nativeEndian = oldEndian }()
+			dec := Decoder{Buf: tc.buf}
+			if err := dec.Decode(tc.got); err != nil {
+				t.Fatalf("could not decode: %v", err)
+			}
+			if !reflect.DeepEqual(tc.got, tc.want) {
+				t.Fatalf("decoding error:\ngot = %#v\nwant= %#v", tc.got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDecodePointers(t *testing.T) {
+	type record struct {
+		Name   string
+		Values []int32
+		ID     uint32
+	}
+
+	// Simulate the C memory pointed to by a record read from HDF5.
+	name := []byte("particle\x00")
+	values := []int32{3, 2, 1}
+
+	want := record{Name: "particle", Values: []int32{3, 2, 1}, ID: 42}
+	rt := reflect.TypeOf(want)
+	buf := make([]byte, 2*rt.Size())
+	putPointer := func(b []byte, p unsafe.Pointer) {
+		copy(b, (*[ptrSize]byte)(unsafe.Pointer(&p))[:])
+	}
+	putPointer(buf[rt.Field(0).Offset:], unsafe.Pointer(&name[0]))
+	off := rt.Field(1).Offset
+	copy(buf[off:], (*[ptrSize]byte)(unsafe.Pointer(&[]uintptr{uintptr(len(values))}[0]))[:])
+	putPointer(buf[off+uintptr(ptrSize):], unsafe.Pointer(&values[0]))
+	nativeEndian.PutUint32(buf[rt.Field(2).Offset:], 42)
+
+	got := make([]record, 2)
+	dec := Decoder{Buf: buf}
+	if err := dec.Decode(&got); err != nil {
+		t.Fatalf("could not decode: %v", err)
+	}
+	if !reflect.DeepEqual(got[0], want) {
+		t.Fatalf("decoding error:\ngot = %#v\nwant= %#v", got[0], want)
+	}
+	if got[1].Name != "" || len(got[1].Values) != 0 {
+		t.Fatalf("decoding error of null pointers: %#v", got[1])
+	}
+
+	// Decoded values must not share memory with the decoded buffer.
+	name[0] = 'P'
+	values[0] = 0
+	if !reflect.DeepEqual(got[0], want) {
+		t.Fatalf("decoded value references C memory: %#v", got[0])
+	}
+
+	var short uint64
+	dec = Decoder{Buf: buf[:4]}
+	if err := dec.Decode(&short); err == nil {
+		t.Fatalf("expected an error decoding a short buffer")
+	}
+	if err := dec.Decode(short); err == nil {
+		t.Fatalf("expected an error decoding into a non-pointer")
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	type inner struct {
+		A int8
+		B float64
+	}
+	type record struct {
+		ID     uint16
+		Flags  [3]bool
+		Inner  inner
+		Points [2]inner
+		Ratio  float32
+	}
+
+	for i, tc := range []struct {
+		v   interface{}
+		got interface{}
+	}{
+		{
+			v: struct {
+				V1 uint8
+				V2 uint64
+				V3 uint8
+				V4 uint16
+			}{1, 2, 3, 4},
+			got: new(struct {
+				V1 uint8
+				V2 uint64
+				V3 uint8
+				V4 uint16
+			}),
+		},
+		{
+			v:   []int32{1, 20, 300, 4000, 50000},
+			got: &[]int32{0, 0, 0, 0, 0},
+		},
+		{
+			v: []record{
+				{1, [3]bool{true, false, true}, inner{-1, 0.5}, [2]inner{{1, 1.5}, {2, 2.5}}, 1},
+				{2, [3]bool{false, true, false}, inner{-2, 1.5}, [2]inner{{3, 3.5}, {4, 4.5}}, 2},
+			},
+			got: &[]record{{}, {}},
+		},
+	} {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			var enc Encoder
+			if err := enc.Encode(tc.v); err != nil {
+				t.Fatalf("could not encode: %v", err)
+			}
+			dec := Decoder{Buf: enc.Buf}
+			if err := dec.Decode(tc.got); err != nil {
+				t.Fatalf("could not decode: %v", err)
+			}
+			if got := reflect.ValueOf(tc.got).Elem().Interface(); !reflect.DeepEqual(got, tc.v) {
+				t.Fatalf("round trip error:\ngot = %#v\nwant= %#v", got, tc.v)
+			}
+		})
+	}
+}
@@ -34,6 +34,12 @@ type Encoder struct {
 // buffer is a Go representation of a C in-memory object that can be appended
 // to e.g. a HDF5 PacketTable.
 //
+// Slices and arrays are encoded element by element. Slices nested within
+// values, such as struct fields or elements of slices, are not supported,
+// as they would be hvl_t variable-length sequences in C: Encode returns an
+// error for them. Earlier versions encoded their elements inline, in
+// a layout Decoder can not read back.
+//
 // Struct values must only have exported fields, otherwise Encode will panic.
 func (enc *Encoder) Encode(data interface{}) error {
 	padding := enc.offset - len(enc.Buf)
@@ -62,6 +68,9 @@ func (enc *Encoder) Encode(data interface{}) error {
 
 	switch rt.Kind() {
 	case reflect.Slice, reflect.Array:
+		if rt.Elem().Kind() == reflect.Slice {
+			return fmt.Errorf("cmem: Encode does not support nested slices (%v)", rt)
+		}
 		for i := 0; i < rv.Len(); i++ {
 			if err := enc.Encode(rv.Index(i).Interface()); err != nil {
 				return err
@@ -70,6 +79,11 @@ func (enc *Encoder) Encode(data interface{}) error {
 		return nil
 
 	case reflect.Struct:
+		for i := 0; i < rt.NumField(); i++ {
+			if rt.Field(i).Type.Kind() == reflect.Slice {
+				return fmt.Errorf("cmem: Encode does not support nested slices (field %s of %v)", rt.Field(i).Name, rt)
+			}
+		}
 		offset := enc.offset
 		for i := 0; i < rv.NumField(); i++ {
 			sfv := rv.Field(i).Interface()
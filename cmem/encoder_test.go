@@ -54,3 +54,16 @@ func TestEncode(t *testing.T) {
 		})
 	}
 }
+
+func TestEncodeNestedSlices(t *testing.T) {
+	for i, v := range []interface{}{
+		struct{ Values []int32 }{[]int32{1, 2}},
+		[][]byte{{1}, {2, 3}},
+		[2][]byte{{1}, {2, 3}},
+	} {
+		var enc Encoder
+		if err := enc.Encode(v); err == nil {
+			t.Errorf("%d: expected an error encoding nested slices of %T", i, v)
+		}
+	}
+}
@@ -5,6 +5,8 @@
 package hdf5
 
 // #include "hdf5.h"
+// #include <stdint.h>
+// #include <stdlib.h>
 //
 // herr_t _go_hdf5_unsilence_errors(void) {
 //   return H5Eset_auto2(H5E_DEFAULT, (H5E_auto2_t)(H5Eprint), stderr);
@@ -13,14 +15,42 @@ package hdf5
 // herr_t _go_hdf5_silence_errors(void) {
 //   return H5Eset_auto2(H5E_DEFAULT, NULL, NULL);
 // }
+//
+// extern herr_t _go_hdf5_ewalk_cb(unsigned n, H5E_error2_t *desc, void *data);
+//
+// static inline herr_t _go_hdf5_ewalk(uintptr_t handle) {
+//   return H5Ewalk2(H5E_DEFAULT, H5E_WALK_UPWARD, (H5E_walk2_t)(_go_hdf5_ewalk_cb), (void*)(handle));
+// }
+//
+// static inline hid_t _go_hdf5_H5E_NOTFOUND() { return H5E_NOTFOUND; }
+// static inline hid_t _go_hdf5_H5E_EXISTS() { return H5E_EXISTS; }
+// static inline hid_t _go_hdf5_H5E_ALREADYEXISTS() { return H5E_ALREADYEXISTS; }
+// static inline hid_t _go_hdf5_H5E_FILEEXISTS() { return H5E_FILEEXISTS; }
+// static inline hid_t _go_hdf5_H5E_CANTOPENFILE() { return H5E_CANTOPENFILE; }
+// static inline hid_t _go_hdf5_H5E_CANTOPENOBJ() { return H5E_CANTOPENOBJ; }
+// static inline hid_t _go_hdf5_H5E_NOTHDF5() { return H5E_NOTHDF5; }
+// static inline hid_t _go_hdf5_H5E_BADVALUE() { return H5E_BADVALUE; }
+// static inline hid_t _go_hdf5_H5E_CANTLOCKFILE() {
+// #if H5_VERSION_GE(1,10,0)
+//   return H5E_CANTLOCKFILE;
+// #else
+//   return -1;
+// #endif
+// }
 import "C"
 
 import (
 	"fmt"
+	"runtime/cgo"
+	"strings"
+	"unsafe"
 )
 
 // DisplayErrors enables/disables HDF5's automatic error printing
 func DisplayErrors(on bool) error {
+	h5lock()
+	defer h5unlock()
+
 	var err error
 	if on {
 		err = h5err(C._go_hdf5_unsilence_errors())
@@ -28,7 +58,7 @@ func DisplayErrors(on bool) error {
 		err = h5err(C._go_hdf5_silence_errors())
 	}
 	if err != nil {
-		return fmt.Errorf("hdf5: could not call H5E_set_auto(): %s", err)
+		return fmt.Errorf("hdf5: could not call H5E_set_auto(): %w", err)
 	}
 	return nil
 }
@@ -39,4 +69,131 @@ func init() {
 	}
 }
 
-// EOF
+// Error is the error returned when a call to the HDF5 library fails.
+// It holds the HDF5 error stack recorded by the failing call.
+//
+// Error can be matched against the ErrXxx sentinel values with errors.Is.
+type Error struct {
+	Code  int          // Negative value returned by the failing call
+	Stack []ErrorFrame // Error stack, from the innermost frame to the API call
+}
+
+// ErrorFrame is an entry of the HDF5 error stack.
+type ErrorFrame struct {
+	Major string // Description of the major error class, e.g. "Dataset"
+	Minor string // Description of the minor error class, e.g. "Object not found"
+	Func  string // Function in which the error occurred
+	File  string // File in which the error occurred
+	Line  int    // Line in File at which the error occurred
+	Desc  string // Description of the error
+
+	major C.hid_t
+	minor C.hid_t
+}
+
+func newErrorFrame(desc *C.H5E_error2_t) ErrorFrame {
+	return ErrorFrame{
+		Major: errorMessage(desc.maj_num),
+		Minor: errorMessage(desc.min_num),
+		Func:  C.GoString(desc.func_name),
+		File:  C.GoString(desc.file_name),
+		Line:  int(desc.line),
+		Desc:  C.GoString(desc.desc),
+		major: desc.maj_num,
+		minor: desc.min_num,
+	}
+}
+
+func (f ErrorFrame) String() string {
+	return fmt.Sprintf("%s:%d %s(): %s (%s: %s)", f.File, f.Line, f.Func, f.Desc, f.Major, f.Minor)
+}
+
+// newError returns an Error for the failure code, filled with the
+// current content of the HDF5 error stack of the calling thread. The caller
+// must hold h5lock since the failed call, so that it runs on the same thread.
+func newError(code int) *Error {
+	e := &Error{Code: code}
+	h := cgo.NewHandle(e)
+	defer h.Delete()
+	C._go_hdf5_ewalk(C.uintptr_t(h))
+	return e
+}
+
+func (e *Error) Error() string {
+	if len(e.Stack) == 0 {
+		return fmt.Sprintf("code %d", e.Code)
+	}
+	var (
+		api   = e.Stack[len(e.Stack)-1]
+		cause = e.Stack[0]
+		msg   strings.Builder
+	)
+	fmt.Fprintf(&msg, "hdf5: %s(): %s", api.Func, api.Desc)
+	if len(e.Stack) > 1 {
+		fmt.Fprintf(&msg, ": %s", cause.Desc)
+	}
+	fmt.Fprintf(&msg, " (%s: %s)", cause.Major, cause.Minor)
+	return msg.String()
+}
+
+// Is reports whether any frame of the error stack matches target,
+// one of the ErrXxx sentinel values.
+func (e *Error) Is(target error) bool {
+	class, ok := target.(*errorClass)
+	if !ok {
+		return false
+	}
+	for _, f := range e.Stack {
+		for _, minor := range class.minors {
+			if f.minor == minor {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func errorMessage(id C.hid_t) string {
+	var typ C.H5E_type_t
+	n := C.H5Eget_msg(id, &typ, nil, 0)
+	if n <= 0 {
+		return ""
+	}
+	buf := make([]C.char, n+1)
+	if C.H5Eget_msg(id, &typ, &buf[0], C.size_t(len(buf))) < 0 {
+		return ""
+	}
+	return C.GoString(&buf[0])
+}
+
+// errorClass is a set of HDF5 minor error classes, used as a sentinel error.
+type errorClass struct {
+	msg    string
+	minors []C.hid_t
+}
+
+func newErrorClass(msg string, minors ...C.hid_t) *errorClass {
+	return &errorClass{msg: msg, minors: minors}
+}
+
+func (e *errorClass) Error() string {
+	return e.msg
+}
+
+// Sentinel errors, to be used with errors.Is to test the cause of an Error.
+var (
+	ErrNotFound       error = newErrorClass("hdf5: object not found", C._go_hdf5_H5E_NOTFOUND())
+	ErrExists         error = newErrorClass("hdf5: object already exists", C._go_hdf5_H5E_EXISTS(), C._go_hdf5_H5E_ALREADYEXISTS())
+	ErrFileExists     error = newErrorClass("hdf5: file already exists", C._go_hdf5_H5E_FILEEXISTS())
+	ErrFileLocked     error = newErrorClass("hdf5: unable to lock file", C._go_hdf5_H5E_CANTLOCKFILE())
+	ErrCantOpenFile   error = newErrorClass("hdf5: unable to open file", C._go_hdf5_H5E_CANTOPENFILE())
+	ErrCantOpenObject error = newErrorClass("hdf5: unable to open object", C._go_hdf5_H5E_CANTOPENOBJ())
+	ErrNotHDF5        error = newErrorClass("hdf5: not an HDF5 file", C._go_hdf5_H5E_NOTHDF5())
+	ErrBadValue       error = newErrorClass("hdf5: bad value", C._go_hdf5_H5E_BADVALUE())
+)
+
+// appendErrorFrame is called for each frame of the error stack walked by newError.
+func appendErrorFrame(data unsafe.Pointer, desc *C.H5E_error2_t) {
+	e := handleValue(data).(*Error)
+	e.Stack = append(e.Stack, newErrorFrame(desc))
+}
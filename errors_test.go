@@ -0,0 +1,57 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hdf5
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestError(t *testing.T) {
+	f, err := CreateFile(fname, F_ACC_TRUNC)
+	if err != nil {
+		t.Fatalf("CreateFile failed: %s", err)
+	}
+	defer os.Remove(fname)
+	defer f.Close()
+
+	g, err := f.CreateGroup("foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.Close()
+
+	_, err = f.OpenDataset("missing")
+	if err == nil {
+		t.Fatal("expected an error opening a missing dataset")
+	}
+	var herr *Error
+	if !errors.As(err, &herr) {
+		t.Fatalf("invalid error type %T", err)
+	}
+	if len(herr.Stack) == 0 {
+		t.Fatalf("empty error stack")
+	}
+	if herr.Error() == "" || herr.Stack[len(herr.Stack)-1].Func == "" {
+		t.Fatalf("missing error details: %#v", herr.Stack)
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if errors.Is(err, ErrExists) {
+		t.Fatalf("unexpected ErrExists match for %v", err)
+	}
+
+	_, err = f.CreateGroup("foo")
+	if !errors.Is(err, ErrExists) {
+		t.Fatalf("expected ErrExists, got %v", err)
+	}
+
+	_, err = OpenFile("does-not-exist.h5", F_ACC_RDONLY)
+	if !errors.Is(err, ErrCantOpenFile) {
+		t.Fatalf("expected ErrCantOpenFile, got %v", err)
+	}
+}
@@ -7,11 +7,21 @@ package hdf5
 // #include "hdf5.h"
 // #include <stdlib.h>
 // #include <string.h>
+// #include <stdint.h>
+//
+// extern herr_t _go_hdf5_aiterate_cb(hid_t id, char *name, H5A_info_t *info, void *data);
+//
+// static inline herr_t _go_hdf5_aiterate(hid_t id, uintptr_t handle) {
+//   return H5Aiterate2(id, H5_INDEX_NAME, H5_ITER_INC, NULL, (H5A_operator2_t)(_go_hdf5_aiterate_cb), (void*)(handle));
+// }
+//
+// static inline int _go_hdf5_ainfo_corder_valid(H5A_info_t *info) { return info->corder_valid ? 1 : 0; }
 import "C"
 
 import (
 	"fmt"
 	"reflect"
+	"runtime/cgo"
 	"unsafe"
 )
 
@@ -19,6 +29,46 @@ type Attribute struct {
 	Identifier
 }
 
+// AttributeHolder is implemented by the objects attributes can be attached to:
+// File, Group, Dataset and committed Datatype.
+type AttributeHolder interface {
+	CreateAttribute(name string, dtype *Datatype, dspace *Dataspace) (*Attribute, error)
+	CreateAttributeWith(name string, dtype *Datatype, dspace *Dataspace, acpl *PropList) (*Attribute, error)
+	OpenAttribute(name string) (*Attribute, error)
+	NumAttributes() (int, error)
+	AttributeNames() ([]string, error)
+	AttributeExists(name string) bool
+	DeleteAttribute(name string) error
+	RenameAttribute(oldName, newName string) error
+	IterateAttributes(fn AttributeFunc) error
+}
+
+var (
+	_ AttributeHolder = (*File)(nil)
+	_ AttributeHolder = (*Group)(nil)
+	_ AttributeHolder = (*Dataset)(nil)
+	_ AttributeHolder = (*Datatype)(nil)
+)
+
+// AttributeInfo describes an attribute.
+type AttributeInfo struct {
+	CreationOrder      int  // Creation order of the attribute
+	CreationOrderValid bool // Whether CreationOrder is tracked for the attribute
+	DataSize           uint // Size of the raw data of the attribute, in bytes
+}
+
+func newAttributeInfo(info *C.H5A_info_t) AttributeInfo {
+	return AttributeInfo{
+		CreationOrder:      int(info.corder),
+		CreationOrderValid: C._go_hdf5_ainfo_corder_valid(info) != 0,
+		DataSize:           uint(info.data_size),
+	}
+}
+
+// AttributeFunc is the type of the function called for each attribute
+// by IterateAttributes.
+type AttributeFunc func(name string, info AttributeInfo) error
+
 func newAttribute(id C.hid_t) *Attribute {
 	return &Attribute{Identifier{id}}
 }
@@ -44,14 +94,89 @@ func openAttribute(id C.hid_t, name string) (*Attribute, error) {
 	return newAttribute(hid), nil
 }
 
+func numAttributes(id C.hid_t) (int, error) {
+	var info C.H5O_info_t
+	if err := h5err(C.H5Oget_info(id, &info)); err != nil {
+		return 0, err
+	}
+	return int(info.num_attrs), nil
+}
+
+func attributeNames(id C.hid_t) ([]string, error) {
+	var names []string
+	err := iterateAttributes(id, func(name string, info AttributeInfo) error {
+		names = append(names, name)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+func attributeExists(id C.hid_t, name string) bool {
+	c_name := C.CString(name)
+	defer C.free(unsafe.Pointer(c_name))
+
+	return C.H5Aexists(id, c_name) > 0
+}
+
+func deleteAttribute(id C.hid_t, name string) error {
+	c_name := C.CString(name)
+	defer C.free(unsafe.Pointer(c_name))
+
+	return h5err(C.H5Adelete(id, c_name))
+}
+
+func renameAttribute(id C.hid_t, oldName, newName string) error {
+	c_old := C.CString(oldName)
+	defer C.free(unsafe.Pointer(c_old))
+	c_new := C.CString(newName)
+	defer C.free(unsafe.Pointer(c_new))
+
+	return h5err(C.H5Arename(id, c_old, c_new))
+}
+
+// attrIterator holds the state of an IterateAttributes call.
+type attrIterator struct {
+	fn  AttributeFunc
+	err error
+}
+
+func iterateAttributes(id C.hid_t, fn AttributeFunc) error {
+	it := &attrIterator{fn: fn}
+	h := cgo.NewHandle(it)
+	defer h.Delete()
+
+	err := h5err(C._go_hdf5_aiterate(id, C.uintptr_t(h)))
+	if it.err != nil {
+		return it.err
+	}
+	return err
+}
+
+func (it *attrIterator) visit(name string, info AttributeInfo) C.herr_t {
+	if err := it.fn(name, info); err != nil {
+		it.err = err
+		return C.H5_ITER_STOP
+	}
+	return C.H5_ITER_CONT
+}
+
 // Access the type of an attribute
 func (s *Attribute) GetType() Identifier {
+	h5lock()
+	defer h5unlock()
+
 	ftype := C.H5Aget_type(s.id)
 	return Identifier{ftype}
 }
 
 // Close releases and terminates access to an attribute.
 func (s *Attribute) Close() error {
+	h5lock()
+	defer h5unlock()
+
 	return s.closeWith(h5aclose)
 }
 
@@ -61,6 +186,9 @@ func h5aclose(id C.hid_t) C.herr_t {
 
 // Space returns an identifier for a copy of the dataspace for a attribute.
 func (s *Attribute) Space() *Dataspace {
+	h5lock()
+	defer h5unlock()
+
 	hid := C.H5Aget_space(s.id)
 	if int(hid) > 0 {
 		return newDataspace(hid)
@@ -70,6 +198,9 @@ func (s *Attribute) Space() *Dataspace {
 
 // Read reads raw data from a attribute into a buffer.
 func (s *Attribute) Read(data interface{}, dtype *Datatype) error {
+	h5lock()
+	defer h5unlock()
+
 	var (
 		addr unsafe.Pointer
 		rv   = reflect.ValueOf(data)
@@ -87,7 +218,7 @@ func (s *Attribute) Read(data interface{}, dtype *Datatype) error {
 	case reflect.String:
 		dtAttr, err := copyDatatype(s.GetType().id)
 		if err != nil {
-			return fmt.Errorf("hdf5: could not access attribute datatype: %v", err)
+			return fmt.Errorf("hdf5: could not access attribute datatype: %w", err)
 		}
 		defer dtAttr.Close()
 
@@ -116,6 +247,9 @@ func (s *Attribute) Read(data interface{}, dtype *Datatype) error {
 
 // Write writes raw data from a buffer to an attribute.
 func (s *Attribute) Write(data interface{}, dtype *Datatype) error {
+	h5lock()
+	defer h5unlock()
+
 	var addr unsafe.Pointer
 	v := reflect.Indirect(reflect.ValueOf(data))
 	switch v.Kind() {
@@ -5,6 +5,7 @@
 package hdf5
 
 import (
+	"fmt"
 	"os"
 	"reflect"
 	"testing"
@@ -78,3 +79,141 @@ func TestWriteAttribute(t *testing.T) {
 		})
 	}
 }
+
+func TestAttributeHolder(t *testing.T) {
+	DisplayErrors(true)
+	defer DisplayErrors(false)
+	defer os.Remove(fname)
+
+	f, err := CreateFile(fname, F_ACC_TRUNC)
+	if err != nil {
+		t.Fatalf("CreateFile failed: %s\n", err)
+	}
+	defer f.Close()
+
+	scalar, err := CreateDataspace(S_SCALAR)
+	if err != nil {
+		t.Fatalf("CreateDataspace failed: %s\n", err)
+	}
+	defer scalar.Close()
+
+	grp, err := f.CreateGroup("grp")
+	if err != nil {
+		t.Fatalf("CreateGroup failed: %s\n", err)
+	}
+	defer grp.Close()
+
+	dset, err := f.CreateDataset("dset", T_NATIVE_USHORT, scalar)
+	if err != nil {
+		t.Fatalf("CreateDataset failed: %s\n", err)
+	}
+	defer dset.Close()
+
+	dtype, err := T_NATIVE_INT32.Copy()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dtype.Close()
+	if err := f.CommitDatatype("dtype", dtype); err != nil {
+		t.Fatalf("CommitDatatype failed: %s\n", err)
+	}
+	if !dtype.Committed() {
+		t.Fatalf("datatype should be committed")
+	}
+
+	for _, tc := range []struct {
+		name   string
+		holder AttributeHolder
+	}{
+		{"file", f},
+		{"group", grp},
+		{"dataset", dset},
+		{"datatype", dtype},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			h := tc.holder
+			for i, name := range []string{"b", "a", "c"} {
+				attr, err := h.CreateAttribute(name, T_NATIVE_INT, scalar)
+				if err != nil {
+					t.Fatalf("CreateAttribute failed: %v", err)
+				}
+				if err := attr.Write(&i, T_NATIVE_INT); err != nil {
+					t.Fatalf("Attribute write failed: %v", err)
+				}
+				attr.Close()
+			}
+
+			if n, err := h.NumAttributes(); err != nil {
+				t.Fatalf("NumAttributes failed: %v", err)
+			} else if n != 3 {
+				t.Fatalf("invalid number of attributes: got=%d, want=%d", n, 3)
+			}
+
+			names, err := h.AttributeNames()
+			if err != nil {
+				t.Fatalf("AttributeNames failed: %v", err)
+			}
+			if want := []string{"a", "b", "c"}; !reflect.DeepEqual(names, want) {
+				t.Fatalf("invalid attribute names: got=%q, want=%q", names, want)
+			}
+
+			if !h.AttributeExists("a") || h.AttributeExists("z") {
+				t.Fatalf("invalid attribute existence")
+			}
+
+			if err := h.RenameAttribute("a", "z"); err != nil {
+				t.Fatalf("RenameAttribute failed: %v", err)
+			}
+			if h.AttributeExists("a") || !h.AttributeExists("z") {
+				t.Fatalf("attribute was not renamed")
+			}
+			attr, err := h.OpenAttribute("z")
+			if err != nil {
+				t.Fatalf("OpenAttribute failed: %v", err)
+			}
+			var v int
+			if err := attr.Read(&v, T_NATIVE_INT); err != nil {
+				t.Fatalf("Attribute read failed: %v", err)
+			}
+			attr.Close()
+			if v != 1 {
+				t.Fatalf("invalid renamed attribute value: got=%d, want=%d", v, 1)
+			}
+
+			if err := h.DeleteAttribute("b"); err != nil {
+				t.Fatalf("DeleteAttribute failed: %v", err)
+			}
+			if err := h.DeleteAttribute("b"); err == nil {
+				t.Fatalf("expected an error deleting a missing attribute")
+			}
+
+			var visited []string
+			err = h.IterateAttributes(func(name string, info AttributeInfo) error {
+				visited = append(visited, name)
+				if info.DataSize != T_NATIVE_INT.Size() {
+					t.Errorf("invalid data size for %q: got=%d, want=%d", name, info.DataSize, T_NATIVE_INT.Size())
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("IterateAttributes failed: %v", err)
+			}
+			if want := []string{"c", "z"}; !reflect.DeepEqual(visited, want) {
+				t.Fatalf("invalid iteration: got=%q, want=%q", visited, want)
+			}
+
+			errStop := fmt.Errorf("stop")
+			visited = visited[:0]
+			err = h.IterateAttributes(func(name string, info AttributeInfo) error {
+				visited = append(visited, name)
+				return errStop
+			})
+			if err != errStop {
+				t.Fatalf("invalid error: got=%v, want=%v", err, errStop)
+			}
+			if len(visited) != 1 {
+				t.Fatalf("iteration did not stop: %q", visited)
+			}
+		})
+	}
+}
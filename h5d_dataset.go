@@ -11,8 +11,8 @@ import "C"
 
 import (
 	"fmt"
-
 	"reflect"
+	"strings"
 	"unsafe"
 )
 
@@ -42,6 +42,9 @@ func createDataset(id C.hid_t, name string, dtype *Datatype, dspace *Dataspace,
 
 // Close releases and terminates access to a dataset.
 func (s *Dataset) Close() error {
+	h5lock()
+	defer h5unlock()
+
 	return s.closeWith(h5dclose)
 }
 
@@ -51,6 +54,9 @@ func h5dclose(id C.hid_t) C.herr_t {
 
 // Space returns an identifier for a copy of the dataspace for a dataset.
 func (s *Dataset) Space() *Dataspace {
+	h5lock()
+	defer h5unlock()
+
 	hid := C.H5Dget_space(s.id)
 	if int(hid) > 0 {
 		return newDataspace(hid)
@@ -59,15 +65,34 @@ func (s *Dataset) Space() *Dataspace {
 }
 
 // ReadSubset reads a subset of raw data from a dataset into a buffer.
+// Values are converted from the datatype of the dataset to the datatype
+// built from the Go type of data when both have the same class.
+// Struct values are read by member name: the members of the dataset
+// may be in any order, and members without a matching field are skipped,
+// but every field of the struct must have a matching member, possibly
+// named with an hdf5 struct tag.
 func (s *Dataset) ReadSubset(data interface{}, memspace, filespace *Dataspace) error {
-	dtype, err := s.Datatype()
-	defer dtype.Close()
-	if err != nil {
-		return err
-	}
+	h5lock()
+	defer h5unlock()
 
 	var addr unsafe.Pointer
 	v := reflect.Indirect(reflect.ValueOf(data))
+	if isStringData(v) {
+		return s.readStrings(v, memspace, filespace)
+	}
+
+	ftype, err := s.Datatype()
+	if err != nil {
+		return err
+	}
+	defer ftype.Close()
+	dtype, err := s.memType(v.Type(), ftype)
+	if err != nil {
+		return err
+	}
+	if dtype != ftype {
+		defer dtype.Close()
+	}
 
 	switch v.Kind() {
 
@@ -106,16 +131,126 @@ func (s *Dataset) Read(data interface{}) error {
 	return s.ReadSubset(data, nil, nil)
 }
 
-// WriteSubset writes a subset of raw data from a buffer to a dataset.
-func (s *Dataset) WriteSubset(data interface{}, memspace, filespace *Dataspace) error {
-	dtype, err := s.Datatype()
-	defer dtype.Close()
+// memType returns the memory datatype to transfer values of the Go type t,
+// or of its elements, to or from the dataset with datatype ftype. The memory
+// datatype is built from the Go type so that HDF5 converts values between the
+// layout of the file, possibly packed or with a different byte order, and the
+// native layout of Go values. ftype is used instead, and values are copied
+// as is, when the memory datatype can not be built, does not have the same
+// class or does not describe the whole Go value. memType returns an error
+// when the members of a compound memory datatype are not all in ftype.
+// The returned datatype must be closed by the caller unless it is ftype.
+func (s *Dataset) memType(t reflect.Type, ftype *Datatype) (*Datatype, error) {
+	vt := t
+	for t != _go_region_ref_t && (t.Kind() == reflect.Array || t.Kind() == reflect.Slice || t.Kind() == reflect.Ptr) {
+		t = t.Elem()
+	}
+	if hasGoPointers(t) {
+		return ftype, nil
+	}
+	mtype, err := NewDataTypeFromType(t)
+	if err != nil {
+		return ftype, nil
+	}
+	if mtype.Class() != ftype.Class() || mtype.Size() != uint(t.Size()) {
+		mtype.Close()
+		return ftype, nil
+	}
+	if err := s.checkMembers(mtype, vt); err != nil {
+		mtype.Close()
+		return nil, err
+	}
+	return mtype, nil
+}
+
+// checkMembers returns an error listing the members of the compound memory
+// datatype mtype, built from the Go type t, which are missing from the
+// datatype of the dataset. HDF5 converts compound values by member name,
+// and would leave the Go fields of the missing members unset.
+func (s *Dataset) checkMembers(mtype *Datatype, t reflect.Type) error {
+	if mtype.Class() != T_COMPOUND {
+		return nil
+	}
+	ftype, err := s.Datatype()
+	if err != nil {
+		return err
+	}
+	defer ftype.Close()
+	if ftype.Class() != T_COMPOUND {
+		return nil
+	}
+	missing, err := missingMembers(&CompoundType{*mtype}, &CompoundType{*ftype}, "")
 	if err != nil {
 		return err
 	}
+	if len(missing) != 0 {
+		return fmt.Errorf("hdf5: fields of %v missing from dataset %q: %s", t, s.Name(), strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// missingMembers returns the names of the members of the compound datatype
+// mtype which are missing from the compound datatype ftype. The names of the
+// members of nested compound datatypes are prefixed by the name of their parent.
+func missingMembers(mtype, ftype *CompoundType, prefix string) ([]string, error) {
+	var missing []string
+	for i := 0; i < mtype.NMembers(); i++ {
+		name := mtype.MemberName(i)
+		j := ftype.MemberIndex(name)
+		if j < 0 {
+			missing = append(missing, prefix+name)
+			continue
+		}
+		if mtype.MemberClass(i) != T_COMPOUND || ftype.MemberClass(j) != T_COMPOUND {
+			continue
+		}
+		mt, err := mtype.MemberType(i)
+		if err != nil {
+			return nil, err
+		}
+		ft, err := ftype.MemberType(j)
+		if err != nil {
+			mt.Close()
+			return nil, err
+		}
+		nested, err := missingMembers(&CompoundType{*mt}, &CompoundType{*ft}, prefix+name+".")
+		mt.Close()
+		ft.Close()
+		if err != nil {
+			return nil, err
+		}
+		missing = append(missing, nested...)
+	}
+	return missing, nil
+}
+
+// WriteSubset writes a subset of raw data from a buffer to a dataset.
+// Values are converted from the datatype built from the Go type of data to
+// the datatype of the dataset when both have the same class. Struct values
+// are written by member name, and every field of the struct must have
+// a matching member, as for ReadSubset.
+func (s *Dataset) WriteSubset(data interface{}, memspace, filespace *Dataspace) error {
+	h5lock()
+	defer h5unlock()
 
 	addr := unsafe.Pointer(nil)
 	v := reflect.Indirect(reflect.ValueOf(data))
+	if isStringData(v) {
+		return s.writeStrings(v, memspace, filespace)
+	}
+
+	ftype, err := s.Datatype()
+	if err != nil {
+		return err
+	}
+	defer ftype.Close()
+	dtype, err := s.memType(v.Type(), ftype)
+	if err != nil {
+		return err
+	}
+	if dtype != ftype {
+		defer dtype.Close()
+	}
 
 	switch v.Kind() {
 
@@ -154,27 +289,244 @@ func (s *Dataset) Write(data interface{}) error {
 	return s.WriteSubset(data, nil, nil)
 }
 
+// SetExtent changes the current dimensions of the dataset to dims.
+// The rank of dims must match the rank of the dataset, and dims must
+// not exceed the maximum dimensions of its dataspace. Only chunked
+// datasets with unlimited or not yet reached maximum dimensions can grow.
+func (s *Dataset) SetExtent(dims []uint) error {
+	h5lock()
+	defer h5unlock()
+
+	space := s.Space()
+	if space == nil {
+		return fmt.Errorf("hdf5: could not access dataspace of dataset %q", s.Name())
+	}
+	rank := space.SimpleExtentNDims()
+	space.Close()
+	if len(dims) == 0 || len(dims) != rank {
+		return fmt.Errorf("hdf5: rank mismatch (got %d, want %d)", len(dims), rank)
+	}
+
+	c_dims := make([]C.hsize_t, len(dims))
+	for i, d := range dims {
+		c_dims[i] = C.hsize_t(d)
+	}
+	return h5err(C.H5Dset_extent(s.id, &c_dims[0]))
+}
+
+// Append grows the first dimension of the dataset to make room for data
+// and writes data into the newly created hyperslab.
+// data must be a slice or an array whose number of elements is a multiple
+// of the number of elements in a row, i.e. the product of all but the first
+// dimension of the dataset. Nested arrays are flattened, unless the dataset
+// holds array elements.
+//
+// Append reads the extent of the dataset, grows it and writes data in
+// separate calls to the library: it is not safe for concurrent use on the
+// same dataset, even through distinct Dataset values, as concurrent appends
+// may write to the same rows.
+func (s *Dataset) Append(data interface{}) error {
+	dtype, err := s.Datatype()
+	if err != nil {
+		return err
+	}
+	class := dtype.Class()
+	dtype.Close()
+
+	rv := reflect.ValueOf(data)
+	v := reflect.Indirect(rv)
+	var n uint
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		n = uint(v.Len())
+		et := v.Type().Elem()
+		for et.Kind() == reflect.Array && class != T_ARRAY {
+			n *= uint(et.Len())
+			et = et.Elem()
+		}
+	default:
+		return fmt.Errorf("hdf5: append expects a slice or an array, got %v", v.Kind())
+	}
+	if n == 0 {
+		return nil
+	}
+
+	space := s.Space()
+	if space == nil {
+		return fmt.Errorf("hdf5: could not access dataspace of dataset %q", s.Name())
+	}
+	defer space.Close()
+	if space.SimpleExtentNDims() <= 0 {
+		return fmt.Errorf("hdf5: can not append to a scalar dataset")
+	}
+	dims, _, err := space.SimpleExtentDims()
+	if err != nil {
+		return err
+	}
+
+	row := uint(1)
+	for _, d := range dims[1:] {
+		row *= d
+	}
+	if row == 0 || n%row != 0 {
+		return fmt.Errorf("hdf5: number of elements (%d) is not a multiple of the row size (%d)", n, row)
+	}
+
+	offset := make([]uint, len(dims))
+	offset[0] = dims[0]
+	count := make([]uint, len(dims))
+	copy(count, dims)
+	count[0] = n / row
+
+	dims[0] += count[0]
+	if err := s.SetExtent(dims); err != nil {
+		return err
+	}
+
+	filespace := s.Space()
+	if filespace == nil {
+		return fmt.Errorf("hdf5: could not access dataspace of dataset %q", s.Name())
+	}
+	defer filespace.Close()
+	if err := filespace.SelectHyperslab(offset, nil, count, nil); err != nil {
+		return err
+	}
+
+	memspace, err := CreateSimpleDataspace(count, nil)
+	if err != nil {
+		return err
+	}
+	defer memspace.Close()
+
+	if rv.Kind() != reflect.Ptr {
+		// WriteSubset needs an addressable value.
+		ptr := reflect.New(v.Type())
+		ptr.Elem().Set(v)
+		data = ptr.Interface()
+	}
+	return s.WriteSubset(data, memspace, filespace)
+}
+
+// StorageSize returns the amount of storage, in bytes, allocated in the
+// file for the raw data of the dataset.
+func (s *Dataset) StorageSize() uint64 {
+	h5lock()
+	defer h5unlock()
+
+	return uint64(C.H5Dget_storage_size(s.id))
+}
+
+// Offset returns the address in the file of the raw data of the dataset.
+// It fails if the layout of the dataset is not contiguous or if its
+// storage space is not yet allocated.
+func (s *Dataset) Offset() (uint64, error) {
+	h5lock()
+	defer h5unlock()
+
+	addr := C.H5Dget_offset(s.id)
+	if addr == C.HADDR_UNDEF {
+		return 0, fmt.Errorf("hdf5: no raw data address for dataset %q", s.Name())
+	}
+	return uint64(addr), nil
+}
+
 // Creates a new attribute at this location. The returned attribute
 // must be closed by the user when it is no longer needed.
 func (s *Dataset) CreateAttribute(name string, dtype *Datatype, dspace *Dataspace) (*Attribute, error) {
+	h5lock()
+	defer h5unlock()
+
 	return createAttribute(s.id, name, dtype, dspace, P_DEFAULT)
 }
 
 // Creates a new attribute at this location. The returned
 // attribute must be closed by the user when it is no longer needed.
 func (s *Dataset) CreateAttributeWith(name string, dtype *Datatype, dspace *Dataspace, acpl *PropList) (*Attribute, error) {
+	h5lock()
+	defer h5unlock()
+
 	return createAttribute(s.id, name, dtype, dspace, acpl)
 }
 
 // Opens an existing attribute. The returned attribute must be closed
 // by the user when it is no longer needed.
 func (s *Dataset) OpenAttribute(name string) (*Attribute, error) {
+	h5lock()
+	defer h5unlock()
+
 	return openAttribute(s.id, name)
 }
 
+// NumAttributes returns the number of attributes attached to the dataset.
+func (s *Dataset) NumAttributes() (int, error) {
+	h5lock()
+	defer h5unlock()
+
+	return numAttributes(s.id)
+}
+
+// AttributeNames returns the names of the attributes attached to the dataset.
+func (s *Dataset) AttributeNames() ([]string, error) {
+	h5lock()
+	defer h5unlock()
+
+	return attributeNames(s.id)
+}
+
+// AttributeExists returns whether an attribute with the specified name
+// is attached to the dataset.
+func (s *Dataset) AttributeExists(name string) bool {
+	h5lock()
+	defer h5unlock()
+
+	return attributeExists(s.id, name)
+}
+
+// DeleteAttribute removes the named attribute from the dataset.
+func (s *Dataset) DeleteAttribute(name string) error {
+	h5lock()
+	defer h5unlock()
+
+	return deleteAttribute(s.id, name)
+}
+
+// RenameAttribute renames the attribute oldName attached to the dataset to newName.
+func (s *Dataset) RenameAttribute(oldName, newName string) error {
+	h5lock()
+	defer h5unlock()
+
+	return renameAttribute(s.id, oldName, newName)
+}
+
+// IterateAttributes calls fn for each attribute attached to the dataset,
+// in increasing name order. The iteration stops at the first error returned by fn.
+func (s *Dataset) IterateAttributes(fn AttributeFunc) error {
+	h5lock()
+	defer h5unlock()
+
+	return iterateAttributes(s.id, fn)
+}
+
+// CreatePropList returns a copy of the dataset creation property list of
+// the dataset, to query its filters for instance. The returned property list
+// must be closed by the user when it is no longer needed.
+func (s *Dataset) CreatePropList() (*PropList, error) {
+	h5lock()
+	defer h5unlock()
+
+	hid := C.H5Dget_create_plist(s.id)
+	if err := checkID(hid); err != nil {
+		return nil, err
+	}
+	return newPropList(hid), nil
+}
+
 // Datatype returns the HDF5 Datatype of the Dataset. The returned
 // datatype must be closed by the user when it is no longer needed.
 func (s *Dataset) Datatype() (*Datatype, error) {
+	h5lock()
+	defer h5unlock()
+
 	dtype_id := C.H5Dget_type(s.id)
 	if dtype_id < 0 {
 		return nil, fmt.Errorf("couldn't open Datatype from Dataset %q", s.Name())
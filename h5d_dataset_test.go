@@ -7,7 +7,9 @@ package hdf5
 import (
 	"os"
 	"reflect"
+	"strings"
 	"testing"
+	"unsafe"
 )
 
 func createDataset1(t *testing.T) error {
@@ -205,3 +207,472 @@ func TestSelectHyperslab(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestDatasetAppend(t *testing.T) {
+	DisplayErrors(true)
+	defer DisplayErrors(false)
+	defer os.Remove(fname)
+
+	f, err := CreateFile(fname, F_ACC_TRUNC)
+	if err != nil {
+		t.Fatalf("CreateFile failed: %s\n", err)
+	}
+	defer f.Close()
+
+	t.Run("1d", func(t *testing.T) {
+		dspace, err := CreateSimpleDataspace([]uint{0}, []uint{S_UNLIMITED})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer dspace.Close()
+
+		dcpl, err := NewPropList(P_DATASET_CREATE)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer dcpl.Close()
+		if err := dcpl.SetChunk([]uint{4}); err != nil {
+			t.Fatal(err)
+		}
+
+		dset, err := f.CreateDatasetWith("dset1d", T_NATIVE_INT32, dspace, dcpl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer dset.Close()
+
+		if err := dset.Append([]int32{1, 2, 3}); err != nil {
+			t.Fatalf("could not append: %v", err)
+		}
+		if err := dset.Append(&[]int32{4, 5}); err != nil {
+			t.Fatalf("could not append: %v", err)
+		}
+		if err := dset.Append([2]int32{6, 7}); err != nil {
+			t.Fatalf("could not append: %v", err)
+		}
+
+		space := dset.Space()
+		defer space.Close()
+		dims, _, err := space.SimpleExtentDims()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !arrayEq(dims, []uint{7}) {
+			t.Fatalf("invalid dims: got=%v, want=%v", dims, []uint{7})
+		}
+
+		got := make([]int32, 7)
+		if err := dset.Read(&got); err != nil {
+			t.Fatal(err)
+		}
+		want := []int32{1, 2, 3, 4, 5, 6, 7}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid data:\ngot= %v\nwant=%v", got, want)
+		}
+	})
+
+	t.Run("nd", func(t *testing.T) {
+		dspace, err := CreateSimpleDataspace([]uint{1, 2, 3}, []uint{S_UNLIMITED, 2, 3})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer dspace.Close()
+
+		dcpl, err := NewPropList(P_DATASET_CREATE)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer dcpl.Close()
+		if err := dcpl.SetChunk([]uint{2, 2, 3}); err != nil {
+			t.Fatal(err)
+		}
+
+		dset, err := f.CreateDatasetWith("dsetnd", T_NATIVE_DOUBLE, dspace, dcpl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer dset.Close()
+
+		if err := dset.Write(&[6]float64{0, 1, 2, 3, 4, 5}); err != nil {
+			t.Fatal(err)
+		}
+		if err := dset.Append([][3]float64{{6, 7, 8}, {9, 10, 11}, {12, 13, 14}, {15, 16, 17}}); err != nil {
+			t.Fatalf("could not append: %v", err)
+		}
+		if err := dset.Append([]float64{18, 19, 20, 21, 22, 23}); err != nil {
+			t.Fatalf("could not append: %v", err)
+		}
+		if err := dset.Append([]float64{1, 2, 3}); err == nil {
+			t.Fatalf("expected an error appending an incomplete row")
+		}
+
+		space := dset.Space()
+		defer space.Close()
+		dims, _, err := space.SimpleExtentDims()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !arrayEq(dims, []uint{4, 2, 3}) {
+			t.Fatalf("invalid dims: got=%v, want=%v", dims, []uint{4, 2, 3})
+		}
+
+		got := make([]float64, 24)
+		if err := dset.Read(&got); err != nil {
+			t.Fatal(err)
+		}
+		for i, v := range got {
+			if v != float64(i) {
+				t.Fatalf("invalid value at index %d: got=%v, want=%v", i, v, float64(i))
+			}
+		}
+
+		if err := dset.SetExtent([]uint{2, 2, 3}); err != nil {
+			t.Fatalf("could not shrink dataset: %v", err)
+		}
+		if err := dset.SetExtent([]uint{2, 2, 4}); err == nil {
+			t.Fatalf("expected an error growing a fixed dimension")
+		}
+		if err := dset.SetExtent([]uint{2}); err == nil {
+			t.Fatalf("expected an error with a rank mismatch")
+		}
+	})
+}
+
+func TestDatasetStrings(t *testing.T) {
+	f, err := CreateFile(fname, F_ACC_TRUNC)
+	if err != nil {
+		t.Fatalf("CreateFile failed: %s", err)
+	}
+	defer os.Remove(fname)
+	defer f.Close()
+
+	data := []string{"foo", "", "héllo, wörld", "a longer string"}
+	dspace, err := CreateSimpleDataspace([]uint{uint(len(data))}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dspace.Close()
+
+	for _, tc := range []struct {
+		name string
+		size int
+		cset CharSet
+		pad  StrPad
+	}{
+		{name: "vlen-ascii", size: T_VARIABLE, cset: T_CSET_ASCII},
+		{name: "vlen-utf8", size: T_VARIABLE, cset: T_CSET_UTF8},
+		{name: "fixed-nullterm", size: 16, cset: T_CSET_UTF8, pad: T_STR_NULLTERM},
+		{name: "fixed-nullpad", size: 15, cset: T_CSET_ASCII, pad: T_STR_NULLPAD},
+		{name: "fixed-spacepad", size: 15, cset: T_CSET_ASCII, pad: T_STR_SPACEPAD},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dtype, err := NewStringType(tc.size, tc.cset)
+			if err != nil {
+				t.Fatalf("NewStringType failed: %s", err)
+			}
+			defer dtype.Close()
+			if got := dtype.IsVariableStr(); got != (tc.size == T_VARIABLE) {
+				t.Fatalf("IsVariableStr: got %v", got)
+			}
+			if got := dtype.CharSet(); got != tc.cset {
+				t.Fatalf("CharSet: got %d, want %d", got, tc.cset)
+			}
+			if tc.size != T_VARIABLE {
+				if err := dtype.SetStrPad(tc.pad); err != nil {
+					t.Fatalf("SetStrPad failed: %s", err)
+				}
+				if got := dtype.StrPad(); got != tc.pad {
+					t.Fatalf("StrPad: got %d, want %d", got, tc.pad)
+				}
+			}
+
+			dset, err := f.CreateDataset(tc.name, dtype, dspace)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer dset.Close()
+			if err := dset.Write(&data); err != nil {
+				t.Fatalf("Write failed: %s", err)
+			}
+			if tc.size != T_VARIABLE {
+				long := append([]string{"a string longer than the type"}, data[1:]...)
+				if err := dset.Write(&long); err == nil {
+					t.Fatalf("expected an error writing a too long string")
+				}
+			}
+
+			got := make([]string, len(data))
+			if err := dset.Read(&got); err != nil {
+				t.Fatalf("Read failed: %s", err)
+			}
+			if !reflect.DeepEqual(got, data) {
+				t.Fatalf("invalid strings:\ngot= %q\nwant=%q", got, data)
+			}
+
+			all, _, err := ReadAll[string](dset)
+			if err != nil {
+				t.Fatalf("ReadAll failed: %s", err)
+			}
+			if !reflect.DeepEqual(all, data) {
+				t.Fatalf("invalid strings from ReadAll:\ngot= %q\nwant=%q", all, data)
+			}
+
+			filespace := dset.Space()
+			defer filespace.Close()
+			if err := filespace.SelectHyperslab([]uint{2}, nil, []uint{1}, nil); err != nil {
+				t.Fatal(err)
+			}
+			memspace, err := CreateSimpleDataspace([]uint{1}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer memspace.Close()
+			var one string
+			if err := dset.ReadSubset(&one, memspace, filespace); err != nil {
+				t.Fatalf("ReadSubset failed: %s", err)
+			}
+			if one != data[2] {
+				t.Fatalf("invalid subset: got %q, want %q", one, data[2])
+			}
+		})
+	}
+}
+
+func TestDatasetPointSelection(t *testing.T) {
+	f, err := CreateFile(fname, F_ACC_TRUNC)
+	if err != nil {
+		t.Fatalf("CreateFile failed: %s", err)
+	}
+	defer os.Remove(fname)
+	defer f.Close()
+
+	var image [8][8]uint16
+	for i := range image {
+		for j := range image[i] {
+			image[i][j] = uint16(10*i + j)
+		}
+	}
+	dspace, err := CreateSimpleDataspace([]uint{8, 8}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dspace.Close()
+	dset, err := f.CreateDataset("image", T_NATIVE_UINT16, dspace)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dset.Close()
+	if err := dset.Write(&image); err != nil {
+		t.Fatal(err)
+	}
+
+	pixels := [][]uint{{7, 7}, {0, 3}, {4, 1}, {2, 6}}
+	filespace := dset.Space()
+	defer filespace.Close()
+	if err := filespace.SelectElements(S_SELECT_SET, pixels); err != nil {
+		t.Fatal(err)
+	}
+	memspace, err := CreateSimpleDataspace([]uint{uint(len(pixels))}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer memspace.Close()
+	got := make([]uint16, len(pixels))
+	if err := dset.ReadSubset(&got, memspace, filespace); err != nil {
+		t.Fatalf("ReadSubset failed: %s", err)
+	}
+	want := []uint16{77, 3, 41, 26}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid gathered pixels: got=%v, want=%v", got, want)
+	}
+}
+
+func TestDatasetConversion(t *testing.T) {
+	f, err := CreateFile(fname, F_ACC_TRUNC)
+	if err != nil {
+		t.Fatalf("CreateFile failed: %s", err)
+	}
+	defer os.Remove(fname)
+	defer f.Close()
+
+	space, err := CreateSimpleDataspace([]uint{4}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer space.Close()
+	dset, err := f.CreateDataset("f32be", T_IEEE_F32BE, space)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dset.Close()
+
+	data := []float64{0.5, -1, 2.25, 1e3}
+	if err := dset.Write(&data); err != nil {
+		t.Fatalf("Write failed: %s", err)
+	}
+	got := make([]float64, len(data))
+	if err := dset.Read(&got); err != nil {
+		t.Fatalf("Read failed: %s", err)
+	}
+	if !reflect.DeepEqual(got, data) {
+		t.Errorf("invalid converted values: got %v, want %v", got, data)
+	}
+	var got32 [4]float32
+	if err := dset.Read(&got32); err != nil {
+		t.Fatalf("Read failed: %s", err)
+	}
+	if want := [4]float32{0.5, -1, 2.25, 1e3}; got32 != want {
+		t.Errorf("invalid converted values: got %v, want %v", got32, want)
+	}
+
+	// Values of another class are transferred without conversion.
+	raw := make([]byte, 16)
+	if err := dset.Read(&raw); err != nil {
+		t.Fatalf("Read failed: %s", err)
+	}
+	if want := []byte{0x3f, 0, 0, 0}; !reflect.DeepEqual(raw[:4], want) {
+		t.Errorf("invalid raw values: got %x, want %x", raw[:4], want)
+	}
+}
+
+func TestDatasetReadByName(t *testing.T) {
+	f, err := CreateFile(fname, F_ACC_TRUNC)
+	if err != nil {
+		t.Fatalf("CreateFile failed: %s", err)
+	}
+	defer os.Remove(fname)
+	defer f.Close()
+
+	type point struct {
+		X, Y float32
+	}
+	type v2 struct {
+		ID    int32
+		Pos   point
+		Value float64
+		Extra [2]int16
+	}
+	data := []v2{
+		{ID: 1, Pos: point{1, 2}, Value: 1.5, Extra: [2]int16{1, 2}},
+		{ID: 2, Pos: point{3, 4}, Value: 2.5, Extra: [2]int16{3, 4}},
+	}
+	dtype, err := NewDatatypeFromValue(v2{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dtype.Close()
+	space, err := CreateSimpleDataspace([]uint{uint(len(data))}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer space.Close()
+	dset, err := f.CreateDataset("records", dtype, space)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dset.Close()
+	if err := dset.Write(&data); err != nil {
+		t.Fatalf("Write failed: %s", err)
+	}
+
+	// older readers, with fewer fields in another order.
+	type v1 struct {
+		Value float64
+		Pos   struct{ Y float32 }
+		ID    int32
+	}
+	got := make([]v1, len(data))
+	if err := dset.Read(&got); err != nil {
+		t.Fatalf("Read failed: %s", err)
+	}
+	for i, d := range data {
+		if got[i].Value != d.Value || got[i].Pos.Y != d.Pos.Y || got[i].ID != d.ID {
+			t.Errorf("invalid record %d: got %+v, want %+v", i, got[i], d)
+		}
+	}
+	all, _, err := ReadAll[v1](dset)
+	if err != nil {
+		t.Fatalf("ReadAll failed: %s", err)
+	}
+	if !reflect.DeepEqual(all, got) {
+		t.Errorf("invalid records: got %+v, want %+v", all, got)
+	}
+
+	// newer readers, with fields missing from the file.
+	type v3 struct {
+		ID    int32
+		Pos   struct{ X, Z float32 }
+		Label int32 `hdf5:"label"`
+	}
+	bad := make([]v3, len(data))
+	err = dset.Read(&bad)
+	if err == nil {
+		t.Fatalf("expected an error reading missing fields")
+	}
+	const want = `fields of []hdf5.v3 missing from dataset "/records": Pos.Z, label`
+	if got := err.Error(); !strings.Contains(got, want) {
+		t.Errorf("unexpected error message: got %q, want it to contain %q", got, want)
+	}
+	if _, _, err := ReadAll[v3](dset); err == nil {
+		t.Errorf("expected an error reading missing fields with ReadAll")
+	}
+}
+
+func TestDatasetReadInsertedCompound(t *testing.T) {
+	f, err := CreateFile(fname, F_ACC_TRUNC)
+	if err != nil {
+		t.Fatalf("CreateFile failed: %s", err)
+	}
+	defer os.Remove(fname)
+	defer f.Close()
+
+	type record struct {
+		Count int32   `hdf5:"count"`
+		Value float64 `hdf5:"value"`
+	}
+	ctype, err := NewCompoundType(int(unsafe.Sizeof(record{})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ctype.Close()
+	if err := ctype.Insert("count", int(unsafe.Offsetof(record{}.Count)), T_NATIVE_INT32); err != nil {
+		t.Fatal(err)
+	}
+	if err := ctype.Insert("value", int(unsafe.Offsetof(record{}.Value)), T_NATIVE_DOUBLE); err != nil {
+		t.Fatal(err)
+	}
+	space, err := CreateSimpleDataspace([]uint{2}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer space.Close()
+	dset, err := f.CreateDataset("records", &ctype.Datatype, space)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dset.Close()
+
+	want := []record{{1, 1.5}, {2, 2.5}}
+	if err := dset.Write(&want); err != nil {
+		t.Fatalf("Write failed: %s", err)
+	}
+	got := make([]record, len(want))
+	if err := dset.Read(&got); err != nil {
+		t.Fatalf("Read failed: %s", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid records: got %+v, want %+v", got, want)
+	}
+
+	// Fields with the same layout but other names are not copied as is.
+	type untagged struct {
+		Count int32
+		Value float64
+	}
+	if err := dset.Read(&[]untagged{{}, {}}); err == nil {
+		t.Errorf("expected an error reading into fields missing from the dataset")
+	}
+	if err := dset.Write(&[]untagged{{3, 3.5}, {4, 4.5}}); err == nil {
+		t.Errorf("expected an error writing fields missing from the dataset")
+	}
+}
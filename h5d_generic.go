@@ -0,0 +1,135 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hdf5
+
+// #include "hdf5.h"
+import "C"
+
+import (
+	"fmt"
+	"reflect"
+	"unsafe"
+)
+
+// ReadAll reads the whole content of the dataset ds into a newly allocated
+// slice of T, and returns it along with the dimensions of the dataset.
+// The memory datatype is built from T with NewDataTypeFromType, and its
+// class must match the class of the datatype of ds.
+// T must not contain Go pointers, such as slices or pointers, unless it is
+// a string type. Struct values are read by member name, as Dataset.Read does.
+func ReadAll[T any](ds *Dataset) ([]T, []uint, error) {
+	h5lock()
+	defer h5unlock()
+
+	rt := reflect.TypeOf((*T)(nil)).Elem()
+	if rt.Kind() != reflect.String && hasGoPointers(rt) {
+		return nil, nil, fmt.Errorf("hdf5: can not read into %T: type holds Go pointers", *new(T))
+	}
+	mtype, err := typedMemType[T](ds)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer mtype.Close()
+	if err := ds.checkMembers(mtype, rt); err != nil {
+		return nil, nil, err
+	}
+
+	space := ds.Space()
+	if space == nil {
+		return nil, nil, fmt.Errorf("hdf5: could not access dataspace of dataset %q", ds.Name())
+	}
+	defer space.Close()
+	dims, _, err := space.SimpleExtentDims()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data := make([]T, space.SimpleExtentNPoints())
+	if len(data) == 0 {
+		return data, dims, nil
+	}
+	if rt.Kind() == reflect.String {
+		err = ds.readStrings(reflect.ValueOf(data), nil, nil)
+	} else {
+		err = h5err(C.H5Dread(ds.id, mtype.id, 0, 0, 0, unsafe.Pointer(&data[0])))
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, dims, nil
+}
+
+// Write writes data to the whole dataset ds. The number of elements of data
+// must match the number of elements of ds.
+// The memory datatype is built from T with NewDataTypeFromType, and its
+// class must match the class of the datatype of ds.
+func Write[T any](ds *Dataset, data []T) error {
+	h5lock()
+	defer h5unlock()
+
+	mtype, err := typedMemType[T](ds)
+	if err != nil {
+		return err
+	}
+	defer mtype.Close()
+	if mtype.hasIllegalGoPointer() {
+		return fmt.Errorf("hdf5: can not write %T: type holds nested Go pointers", *new(T))
+	}
+
+	space := ds.Space()
+	if space == nil {
+		return fmt.Errorf("hdf5: could not access dataspace of dataset %q", ds.Name())
+	}
+	n := space.SimpleExtentNPoints()
+	space.Close()
+	if len(data) != n {
+		return fmt.Errorf("hdf5: number of elements mismatch (got %d, want %d)", len(data), n)
+	}
+	if n == 0 {
+		return nil
+	}
+	if v := reflect.ValueOf(data); isStringData(v) {
+		return ds.writeStrings(v, nil, nil)
+	}
+	return h5err(C.H5Dwrite(ds.id, mtype.id, 0, 0, 0, unsafe.Pointer(&data[0])))
+}
+
+// typedMemType returns the memory datatype for T, checking its class
+// against the datatype of ds.
+func typedMemType[T any](ds *Dataset) (*Datatype, error) {
+	mtype, err := NewDataTypeFromType(reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		return nil, err
+	}
+	ftype, err := ds.Datatype()
+	if err != nil {
+		mtype.Close()
+		return nil, err
+	}
+	defer ftype.Close()
+	if mc, fc := mtype.Class(), ftype.Class(); mc != fc {
+		mtype.Close()
+		return nil, fmt.Errorf("hdf5: type class mismatch for %T (got %v, want %v)", *new(T), mc, fc)
+	}
+	return mtype, nil
+}
+
+// hasGoPointers returns whether values of type t hold Go pointers.
+func hasGoPointers(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.String, reflect.Slice, reflect.Ptr, reflect.Map, reflect.Chan,
+		reflect.Func, reflect.Interface, reflect.UnsafePointer:
+		return true
+	case reflect.Array:
+		return hasGoPointers(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if hasGoPointers(t.Field(i).Type) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hdf5
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestReadAllWrite(t *testing.T) {
+	f, err := CreateFile(fname, F_ACC_TRUNC)
+	if err != nil {
+		t.Fatalf("CreateFile failed: %s", err)
+	}
+	defer os.Remove(fname)
+	defer f.Close()
+
+	type point struct {
+		X, Y float64
+		N    int32
+	}
+
+	dims := []uint{2, 3}
+	dspace, err := CreateSimpleDataspace(dims, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dspace.Close()
+
+	t.Run("float64", func(t *testing.T) {
+		dset, err := f.CreateDataset("floats", T_NATIVE_DOUBLE, dspace)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer dset.Close()
+
+		want := []float64{1, 2, 3, 4, 5, 6}
+		if err := Write(dset, want); err != nil {
+			t.Fatalf("Write failed: %s", err)
+		}
+		got, gotDims, err := ReadAll[float64](dset)
+		if err != nil {
+			t.Fatalf("ReadAll failed: %s", err)
+		}
+		if !reflect.DeepEqual(got, want) || !reflect.DeepEqual(gotDims, dims) {
+			t.Fatalf("invalid data: got=%v %v, want=%v %v", got, gotDims, want, dims)
+		}
+
+		// conversion within the same type class.
+		got32, _, err := ReadAll[float32](dset)
+		if err != nil {
+			t.Fatalf("ReadAll[float32] failed: %s", err)
+		}
+		for i := range want {
+			if float64(got32[i]) != want[i] {
+				t.Fatalf("invalid converted data: got=%v, want=%v", got32, want)
+			}
+		}
+
+		if _, _, err := ReadAll[int32](dset); err == nil {
+			t.Fatalf("expected a type class mismatch error")
+		}
+		if _, _, err := ReadAll[[]float64](dset); err == nil {
+			t.Fatalf("expected an error reading into Go pointers")
+		}
+		if err := Write(dset, want[:4]); err == nil {
+			t.Fatalf("expected a number of elements mismatch error")
+		}
+	})
+
+	t.Run("compound", func(t *testing.T) {
+		dtype, err := NewDatatypeFromValue(point{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer dtype.Close()
+		dset, err := f.CreateDataset("points", dtype, dspace)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer dset.Close()
+
+		want := make([]point, 6)
+		for i := range want {
+			want[i] = point{X: float64(i), Y: -float64(i), N: int32(i * i)}
+		}
+		if err := Write(dset, want); err != nil {
+			t.Fatalf("Write failed: %s", err)
+		}
+		got, _, err := ReadAll[point](dset)
+		if err != nil {
+			t.Fatalf("ReadAll failed: %s", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid data: got=%v, want=%v", got, want)
+		}
+	})
+}
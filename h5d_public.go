@@ -5,6 +5,12 @@
 package hdf5
 
 // #include "hdf5.h"
+//
+// #if H5_VERSION_GE(1,10,0)
+// #define _go_hdf5_H5D_VIRTUAL H5D_VIRTUAL
+// #else
+// #define _go_hdf5_H5D_VIRTUAL 3
+// #endif
 import "C"
 
 // Used to unset chunk cache configuration parameter.
@@ -14,3 +20,32 @@ const (
 	D_CHUNK_CACHE_NBYTES_DEFAULT int     = -1 // The total size of the raw data chunk cache for this dataset
 	D_CHUNK_CACHE_W0_DEFAULT     float64 = -1 // The chunk preemption policy for this dataset
 )
+
+// Layout is the storage layout of the raw data of a dataset.
+type Layout C.H5D_layout_t
+
+const (
+	D_COMPACT    Layout = C.H5D_COMPACT          // Raw data is stored in the object header
+	D_CONTIGUOUS Layout = C.H5D_CONTIGUOUS       // Raw data is stored contiguously in the file
+	D_CHUNKED    Layout = C.H5D_CHUNKED          // Raw data is stored in separate chunks
+	D_VIRTUAL    Layout = C._go_hdf5_H5D_VIRTUAL // Raw data is drawn from other datasets, since HDF5 1.10
+)
+
+// AllocTime is the time at which storage space is allocated for a dataset.
+type AllocTime C.H5D_alloc_time_t
+
+const (
+	D_ALLOC_TIME_DEFAULT AllocTime = C.H5D_ALLOC_TIME_DEFAULT // Default allocation time for the storage layout
+	D_ALLOC_TIME_EARLY   AllocTime = C.H5D_ALLOC_TIME_EARLY   // Allocate all space when the dataset is created
+	D_ALLOC_TIME_LATE    AllocTime = C.H5D_ALLOC_TIME_LATE    // Allocate all space when data is first written
+	D_ALLOC_TIME_INCR    AllocTime = C.H5D_ALLOC_TIME_INCR    // Allocate space incrementally, as data is written
+)
+
+// FillTime is the time at which fill values are written to a dataset.
+type FillTime C.H5D_fill_time_t
+
+const (
+	D_FILL_TIME_ALLOC FillTime = C.H5D_FILL_TIME_ALLOC // Write fill values when storage space is allocated
+	D_FILL_TIME_NEVER FillTime = C.H5D_FILL_TIME_NEVER // Never write fill values
+	D_FILL_TIME_IFSET FillTime = C.H5D_FILL_TIME_IFSET // Write fill values on allocation if a fill value was set
+)
@@ -0,0 +1,258 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hdf5
+
+// #include "hdf5.h"
+// #include <stdlib.h>
+// #include <string.h>
+import "C"
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"unsafe"
+)
+
+// isStringData returns whether v holds a string, or a slice or an array
+// of strings.
+func isStringData(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.String:
+		return true
+	case reflect.Slice, reflect.Array:
+		return v.Type().Elem().Kind() == reflect.String
+	}
+	return false
+}
+
+// stringBuffer describes the memory buffer exchanged with HDF5 when
+// reading or writing strings.
+type stringBuffer struct {
+	mtype *Datatype  // memory datatype
+	space *Dataspace // dataspace describing the buffer
+	owned bool       // whether space must be closed
+	n     int        // number of elements of the buffer
+	size  int        // size of an element, in bytes
+	vlen  bool       // whether strings are variable-length
+	pad   StrPad     // padding of fixed-length strings
+}
+
+func (buf *stringBuffer) close() {
+	buf.mtype.Close()
+	if buf.owned {
+		buf.space.Close()
+	}
+}
+
+// newStringBuffer returns the description of the memory buffer for a
+// transfer of strings between memspace and filespace of the dataset.
+// The returned buffer description must be closed by the caller.
+func (s *Dataset) newStringBuffer(memspace, filespace *Dataspace) (*stringBuffer, error) {
+	ftype, err := s.Datatype()
+	if err != nil {
+		return nil, err
+	}
+	defer ftype.Close()
+	if ftype.Class() != T_STRING {
+		return nil, fmt.Errorf("hdf5: dataset %q does not hold strings", s.Name())
+	}
+
+	buf := stringBuffer{vlen: ftype.IsVariableStr()}
+	if buf.vlen {
+		buf.mtype, err = NewStringType(T_VARIABLE, ftype.CharSet())
+		buf.size = int(unsafe.Sizeof(uintptr(0)))
+	} else {
+		buf.mtype, err = ftype.Copy()
+		buf.size = int(ftype.Size())
+		buf.pad = ftype.StrPad()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case memspace != nil:
+		buf.space = memspace
+	case filespace != nil:
+		buf.space = filespace
+	default:
+		buf.space = s.Space()
+		if buf.space == nil {
+			buf.mtype.Close()
+			return nil, fmt.Errorf("hdf5: could not access dataspace of dataset %q", s.Name())
+		}
+		buf.owned = true
+	}
+	buf.n = buf.space.SimpleExtentNPoints()
+	return &buf, nil
+}
+
+// readStrings reads strings from the dataset into v, a settable string or
+// a slice or an array of strings.
+func (s *Dataset) readStrings(v reflect.Value, memspace, filespace *Dataspace) error {
+	if v.Kind() != reflect.Slice && !v.CanSet() {
+		return fmt.Errorf("hdf5: read expects a pointer value")
+	}
+	buf, err := s.newStringBuffer(memspace, filespace)
+	if err != nil {
+		return err
+	}
+	defer buf.close()
+	if err := checkStringLen(v, buf.n); err != nil {
+		return err
+	}
+	if buf.n == 0 {
+		return nil
+	}
+
+	// Use zeroed memory, so elements outside of the memory selection
+	// read as empty strings.
+	cbuf := C.calloc(C.size_t(buf.n), C.size_t(buf.size))
+	if cbuf == nil {
+		return fmt.Errorf("hdf5: could not allocate memory for %d strings", buf.n)
+	}
+	defer C.free(cbuf)
+
+	var memspace_id, filespace_id C.hid_t
+	if memspace != nil {
+		memspace_id = memspace.id
+	}
+	if filespace != nil {
+		filespace_id = filespace.id
+	}
+	if err := h5err(C.H5Dread(s.id, buf.mtype.id, memspace_id, filespace_id, 0, cbuf)); err != nil {
+		return err
+	}
+
+	if buf.vlen {
+		ptrs := unsafe.Slice((**C.char)(cbuf), buf.n)
+		for i, p := range ptrs {
+			var str string
+			if p != nil {
+				str = C.GoString(p)
+			}
+			setString(v, i, str)
+		}
+		return h5err(C.H5Dvlen_reclaim(buf.mtype.id, buf.space.id, 0, cbuf))
+	}
+
+	raw := C.GoBytes(cbuf, C.int(buf.n*buf.size))
+	for i := 0; i < buf.n; i++ {
+		setString(v, i, trimString(raw[i*buf.size:(i+1)*buf.size], buf.pad))
+	}
+	return nil
+}
+
+// writeStrings writes strings from v, a string or a slice or an array of
+// strings, to the dataset.
+func (s *Dataset) writeStrings(v reflect.Value, memspace, filespace *Dataspace) error {
+	buf, err := s.newStringBuffer(memspace, filespace)
+	if err != nil {
+		return err
+	}
+	defer buf.close()
+	if err := checkStringLen(v, buf.n); err != nil {
+		return err
+	}
+	if buf.n == 0 {
+		return nil
+	}
+
+	cbuf := C.calloc(C.size_t(buf.n), C.size_t(buf.size))
+	if cbuf == nil {
+		return fmt.Errorf("hdf5: could not allocate memory for %d strings", buf.n)
+	}
+	defer C.free(cbuf)
+
+	if buf.vlen {
+		ptrs := unsafe.Slice((**C.char)(cbuf), buf.n)
+		for i := range ptrs {
+			ptrs[i] = C.CString(getString(v, i))
+		}
+		defer func() {
+			for _, p := range ptrs {
+				C.free(unsafe.Pointer(p))
+			}
+		}()
+	} else {
+		raw := unsafe.Slice((*byte)(cbuf), buf.n*buf.size)
+		for i := 0; i < buf.n; i++ {
+			if err := padString(raw[i*buf.size:(i+1)*buf.size], getString(v, i), buf.pad); err != nil {
+				return err
+			}
+		}
+	}
+
+	var memspace_id, filespace_id C.hid_t
+	if memspace != nil {
+		memspace_id = memspace.id
+	}
+	if filespace != nil {
+		filespace_id = filespace.id
+	}
+	return h5err(C.H5Dwrite(s.id, buf.mtype.id, memspace_id, filespace_id, 0, cbuf))
+}
+
+func checkStringLen(v reflect.Value, n int) error {
+	if v.Kind() == reflect.String {
+		if n != 1 {
+			return fmt.Errorf("hdf5: can not transfer %d strings with a single string", n)
+		}
+		return nil
+	}
+	if v.Len() < n {
+		return fmt.Errorf("hdf5: number of strings mismatch (got %d, want %d)", v.Len(), n)
+	}
+	return nil
+}
+
+func getString(v reflect.Value, i int) string {
+	if v.Kind() == reflect.String {
+		return v.String()
+	}
+	return v.Index(i).String()
+}
+
+func setString(v reflect.Value, i int, str string) {
+	if v.Kind() == reflect.String {
+		v.SetString(str)
+		return
+	}
+	v.Index(i).SetString(str)
+}
+
+// trimString returns the content of the fixed-length string b, padded with pad.
+func trimString(b []byte, pad StrPad) string {
+	switch pad {
+	case T_STR_SPACEPAD:
+		b = bytes.TrimRight(b, " ")
+	default:
+		if i := bytes.IndexByte(b, 0); i >= 0 {
+			b = b[:i]
+		}
+	}
+	return string(b)
+}
+
+// padString copies str into the fixed-length string dst, padding it
+// according to pad. dst must be zeroed. padString returns an error when
+// str does not fit in dst.
+func padString(dst []byte, str string, pad StrPad) error {
+	n := len(dst)
+	if pad == T_STR_NULLTERM && n > 0 {
+		n-- // room for the null terminator.
+	}
+	if len(str) > n {
+		return fmt.Errorf("hdf5: string of %d bytes longer than the %d bytes of fixed-length strings", len(str), n)
+	}
+	copy(dst, str)
+	if pad == T_STR_SPACEPAD {
+		for i := len(str); i < len(dst); i++ {
+			dst[i] = ' '
+		}
+	}
+	return nil
+}
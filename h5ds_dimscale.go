@@ -0,0 +1,170 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hdf5
+
+// #include "hdf5.h"
+// #include "hdf5_hl.h"
+// #include <stdint.h>
+// #include <stdlib.h>
+//
+// extern herr_t _go_hdf5_dsiterate_cb(hid_t did, unsigned dim, hid_t scale, void *data);
+//
+// static inline herr_t _go_hdf5_dsiterate(hid_t did, unsigned dim, uintptr_t handle) {
+//   return H5DSiterate_scales(did, dim, NULL, (H5DS_iterate_t)(_go_hdf5_dsiterate_cb), (void*)(handle));
+// }
+import "C"
+
+import (
+	"fmt"
+	"runtime/cgo"
+	"unsafe"
+)
+
+// SetScale converts the dataset into a dimension scale, with the optional
+// dimension name name.
+func (s *Dataset) SetScale(name string) error {
+	h5lock()
+	defer h5unlock()
+
+	var c_name *C.char
+	if name != "" {
+		c_name = C.CString(name)
+		defer C.free(unsafe.Pointer(c_name))
+	}
+	return h5err(C.H5DSset_scale(s.id, c_name))
+}
+
+// IsScale returns whether the dataset is a dimension scale.
+func (s *Dataset) IsScale() bool {
+	h5lock()
+	defer h5unlock()
+
+	return C.H5DSis_scale(s.id) > 0
+}
+
+// ScaleName returns the dimension name of a dimension scale, as set by SetScale.
+func (s *Dataset) ScaleName() (string, error) {
+	h5lock()
+	defer h5unlock()
+
+	n := C.H5DSget_scale_name(s.id, nil, 0)
+	if n < 0 {
+		return "", h5err(C.herr_t(n))
+	}
+	if n == 0 {
+		return "", nil
+	}
+	buf := make([]C.char, n+1)
+	if rc := C.H5DSget_scale_name(s.id, &buf[0], C.size_t(len(buf))); rc < 0 {
+		return "", h5err(C.herr_t(rc))
+	}
+	return C.GoString(&buf[0]), nil
+}
+
+// AttachScale attaches the dimension scale scale to the dimension dim
+// of the dataset.
+func (s *Dataset) AttachScale(scale *Dataset, dim int) error {
+	h5lock()
+	defer h5unlock()
+
+	return h5err(C.H5DSattach_scale(s.id, scale.id, C.uint(dim)))
+}
+
+// DetachScale detaches the dimension scale scale from the dimension dim
+// of the dataset.
+func (s *Dataset) DetachScale(scale *Dataset, dim int) error {
+	h5lock()
+	defer h5unlock()
+
+	return h5err(C.H5DSdetach_scale(s.id, scale.id, C.uint(dim)))
+}
+
+// IsAttached returns whether the dimension scale scale is attached to the
+// dimension dim of the dataset.
+func (s *Dataset) IsAttached(scale *Dataset, dim int) (bool, error) {
+	h5lock()
+	defer h5unlock()
+
+	rc := C.H5DSis_attached(s.id, scale.id, C.uint(dim))
+	if err := h5err(C.herr_t(rc)); err != nil {
+		return false, err
+	}
+	return rc > 0, nil
+}
+
+// NumScales returns the number of dimension scales attached to the
+// dimension dim of the dataset.
+func (s *Dataset) NumScales(dim int) (int, error) {
+	h5lock()
+	defer h5unlock()
+
+	n := C.H5DSget_num_scales(s.id, C.uint(dim))
+	if err := h5err(C.herr_t(n)); err != nil {
+		return 0, err
+	}
+	return int(n), nil
+}
+
+// DimensionScales returns the dimension scales attached to the dimension
+// dim of the dataset. The returned datasets must be closed by the user
+// when they are no longer needed.
+func (s *Dataset) DimensionScales(dim int) ([]*Dataset, error) {
+	h5lock()
+	defer h5unlock()
+
+	var l scaleLister
+	h := cgo.NewHandle(&l)
+	defer h.Delete()
+	if err := h5err(C._go_hdf5_dsiterate(s.id, C.uint(dim), C.uintptr_t(h))); err != nil {
+		for _, scale := range l.scales {
+			scale.Close()
+		}
+		return nil, err
+	}
+	return l.scales, nil
+}
+
+// scaleLister collects the dimension scales iterated over by DimensionScales.
+type scaleLister struct {
+	scales []*Dataset
+}
+
+func (l *scaleLister) visit(scale C.hid_t) C.herr_t {
+	// The identifier is closed by the library once visited.
+	if C.H5Iinc_ref(scale) < 0 {
+		return -1
+	}
+	l.scales = append(l.scales, newDataset(scale, nil))
+	return C.H5_ITER_CONT
+}
+
+// SetDimLabel sets the label of the dimension dim of the dataset.
+func (s *Dataset) SetDimLabel(dim int, label string) error {
+	h5lock()
+	defer h5unlock()
+
+	c_label := C.CString(label)
+	defer C.free(unsafe.Pointer(c_label))
+	return h5err(C.H5DSset_label(s.id, C.uint(dim), c_label))
+}
+
+// DimLabel returns the label of the dimension dim of the dataset.
+func (s *Dataset) DimLabel(dim int) (string, error) {
+	h5lock()
+	defer h5unlock()
+
+	n := C.H5DSget_label(s.id, C.uint(dim), nil, 0)
+	if n < 0 {
+		return "", fmt.Errorf("hdf5: could not get label of dimension %d: %w", dim, h5err(C.herr_t(n)))
+	}
+	if n == 0 {
+		return "", nil
+	}
+	buf := make([]C.char, n+1)
+	if rc := C.H5DSget_label(s.id, C.uint(dim), &buf[0], C.size_t(len(buf))); rc < 0 {
+		return "", h5err(C.herr_t(rc))
+	}
+	return C.GoString(&buf[0]), nil
+}
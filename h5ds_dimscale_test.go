@@ -0,0 +1,103 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hdf5
+
+import (
+	"os"
+	"testing"
+)
+
+func TestDimensionScales(t *testing.T) {
+	f, err := CreateFile(fname, F_ACC_TRUNC)
+	if err != nil {
+		t.Fatalf("CreateFile failed: %s", err)
+	}
+	defer os.Remove(fname)
+	defer f.Close()
+
+	create := func(name string, dims ...uint) *Dataset {
+		t.Helper()
+		space, err := CreateSimpleDataspace(dims, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer space.Close()
+		dset, err := f.CreateDataset(name, T_NATIVE_DOUBLE, space)
+		if err != nil {
+			t.Fatalf("CreateDataset(%q) failed: %s", name, err)
+		}
+		return dset
+	}
+
+	data := create("data", 3, 4)
+	defer data.Close()
+	x := create("x", 4)
+	defer x.Close()
+	y := create("y", 3)
+	defer y.Close()
+
+	if data.IsScale() || x.IsScale() {
+		t.Fatalf("datasets unexpectedly are dimension scales")
+	}
+	if err := x.SetScale("X axis"); err != nil {
+		t.Fatalf("SetScale failed: %s", err)
+	}
+	if err := y.SetScale(""); err != nil {
+		t.Fatalf("SetScale failed: %s", err)
+	}
+	if !x.IsScale() || !y.IsScale() {
+		t.Fatalf("datasets are not dimension scales")
+	}
+	if name, err := x.ScaleName(); err != nil || name != "X axis" {
+		t.Errorf("invalid scale name: got %q, %v", name, err)
+	}
+
+	if err := data.AttachScale(y, 0); err != nil {
+		t.Fatalf("AttachScale failed: %s", err)
+	}
+	if err := data.AttachScale(x, 1); err != nil {
+		t.Fatalf("AttachScale failed: %s", err)
+	}
+	if ok, err := data.IsAttached(x, 1); err != nil || !ok {
+		t.Errorf("x is not attached to dimension 1: %v", err)
+	}
+	if ok, err := data.IsAttached(x, 0); err != nil || ok {
+		t.Errorf("x is attached to dimension 0: %v", err)
+	}
+	if n, err := data.NumScales(1); err != nil || n != 1 {
+		t.Errorf("invalid number of scales: got %d, %v", n, err)
+	}
+
+	scales, err := data.DimensionScales(1)
+	if err != nil {
+		t.Fatalf("DimensionScales failed: %s", err)
+	}
+	if len(scales) != 1 || scales[0].Name() != "/x" {
+		t.Errorf("invalid dimension scales: %v", scales)
+	}
+	for _, scale := range scales {
+		scale.Close()
+	}
+
+	if err := data.SetDimLabel(0, "rows"); err != nil {
+		t.Fatalf("SetDimLabel failed: %s", err)
+	}
+	if label, err := data.DimLabel(0); err != nil || label != "rows" {
+		t.Errorf("invalid label: got %q, %v", label, err)
+	}
+	if label, err := data.DimLabel(1); err != nil || label != "" {
+		t.Errorf("invalid label: got %q, %v", label, err)
+	}
+
+	if err := data.DetachScale(x, 1); err != nil {
+		t.Fatalf("DetachScale failed: %s", err)
+	}
+	if n, err := data.NumScales(1); err != nil || n != 0 {
+		t.Errorf("invalid number of scales after detach: got %d, %v", n, err)
+	}
+	if err := data.DetachScale(x, 1); err == nil {
+		t.Errorf("expected an error detaching an unattached scale")
+	}
+}
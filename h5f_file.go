@@ -45,13 +45,22 @@ func newFile(id C.hid_t) *File {
 
 // Creates an HDF5 file.
 func CreateFile(name string, flags int) (*File, error) {
+	return CreateFileWith(name, flags, P_DEFAULT, P_DEFAULT)
+}
+
+// CreateFileWith creates an HDF5 file with the file creation property list fcpl
+// and the file access property list fapl.
+// The returned file must be closed by the user when it is no longer needed.
+func CreateFileWith(name string, flags int, fcpl, fapl *PropList) (*File, error) {
+	h5lock()
+	defer h5unlock()
+
 	c_name := C.CString(name)
 	defer C.free(unsafe.Pointer(c_name))
 
-	// FIXME: file props
-	hid := C.H5Fcreate(c_name, C.uint(flags), P_DEFAULT.id, P_DEFAULT.id)
+	hid := C.H5Fcreate(c_name, C.uint(flags), fcpl.id, fapl.id)
 	if err := checkID(hid); err != nil {
-		return nil, fmt.Errorf("error creating hdf5 file: %s", err)
+		return nil, fmt.Errorf("error creating hdf5 file: %w", err)
 	}
 	return newFile(hid), nil
 }
@@ -59,13 +68,22 @@ func CreateFile(name string, flags int) (*File, error) {
 // Open opens and returns an an existing HDF5 file. The returned
 // file must be closed by the user when it is no longer needed.
 func OpenFile(name string, flags int) (*File, error) {
+	return OpenFileWith(name, flags, P_DEFAULT)
+}
+
+// OpenFileWith opens and returns an existing HDF5 file with the file access
+// property list fapl. The returned file must be closed by the user when it
+// is no longer needed.
+func OpenFileWith(name string, flags int, fapl *PropList) (*File, error) {
+	h5lock()
+	defer h5unlock()
+
 	c_name := C.CString(name)
 	defer C.free(unsafe.Pointer(c_name))
 
-	// FIXME: file props
-	hid := C.H5Fopen(c_name, C.uint(flags), P_DEFAULT.id)
+	hid := C.H5Fopen(c_name, C.uint(flags), fapl.id)
 	if err := checkID(hid); err != nil {
-		return nil, fmt.Errorf("error opening hdf5 file: %s", err)
+		return nil, fmt.Errorf("error opening hdf5 file: %w", err)
 	}
 	return newFile(hid), nil
 }
@@ -73,15 +91,44 @@ func OpenFile(name string, flags int) (*File, error) {
 // ReOpen returns a new identifier for a previously-opened HDF5 file.
 // The returned file must be closed by the user when it is no longer needed.
 func (f *File) ReOpen() (*File, error) {
+	h5lock()
+	defer h5unlock()
+
 	hid := C.H5Freopen(f.id)
 	if err := checkID(hid); err != nil {
-		return nil, fmt.Errorf("error reopening hdf5 file: %s", err)
+		return nil, fmt.Errorf("error reopening hdf5 file: %w", err)
+	}
+	return newFile(hid), nil
+}
+
+// OpenFileImage opens an HDF5 file image held in memory, as returned by
+// File.Image. flags is either F_ACC_RDONLY or F_ACC_RDWR.
+// The image is copied by the HDF5 library, so changes made through the
+// returned file are not reflected into image.
+// The returned file must be closed by the user when it is no longer needed.
+func OpenFileImage(image []byte, flags int) (*File, error) {
+	h5lock()
+	defer h5unlock()
+
+	if len(image) == 0 {
+		return nil, fmt.Errorf("hdf5: empty file image")
+	}
+	var c_flags C.uint
+	if flags&F_ACC_RDWR != 0 {
+		c_flags |= C.H5LT_FILE_IMAGE_OPEN_RW
+	}
+	hid := C.H5LTopen_file_image(unsafe.Pointer(&image[0]), C.size_t(len(image)), c_flags)
+	if err := checkID(hid); err != nil {
+		return nil, fmt.Errorf("error opening hdf5 file image: %w", err)
 	}
 	return newFile(hid), nil
 }
 
 // IsHDF5 Determines whether a file is in the HDF5 format.
 func IsHDF5(name string) bool {
+	h5lock()
+	defer h5unlock()
+
 	c_name := C.CString(name)
 	defer C.free(unsafe.Pointer(c_name))
 
@@ -90,6 +137,9 @@ func IsHDF5(name string) bool {
 
 // Close closes the file.
 func (f *File) Close() error {
+	h5lock()
+	defer h5unlock()
+
 	return f.closeWith(h5fclose)
 }
 
@@ -99,13 +149,41 @@ func h5fclose(id C.hid_t) C.herr_t {
 
 // Flushes all buffers associated with a file to disk.
 func (f *File) Flush(scope Scope) error {
+	h5lock()
+	defer h5unlock()
+
 	// herr_t H5Fflush(hid_t object_id, H5F_scope_t scope )
 	return h5err(C.H5Fflush(f.id, C.H5F_scope_t(scope)))
 }
 
+// Image returns a copy of the image of the file, as stored on disk.
+// Image flushes the file before retrieving its image.
+func (f *File) Image() ([]byte, error) {
+	h5lock()
+	defer h5unlock()
+
+	// ssize_t H5Fget_file_image(hid_t file_id, void *buf_ptr, size_t buf_len)
+	n := C.H5Fget_file_image(f.id, nil, 0)
+	if n < 0 {
+		return nil, h5err(C.herr_t(n))
+	}
+	buf := make([]byte, int(n))
+	if n == 0 {
+		return buf, nil
+	}
+	n = C.H5Fget_file_image(f.id, unsafe.Pointer(&buf[0]), C.size_t(len(buf)))
+	if n < 0 {
+		return nil, h5err(C.herr_t(n))
+	}
+	return buf[:n], nil
+}
+
 // FIXME
 // Retrieves name of file to which object belongs.
 func (f *File) FileName() string {
+	h5lock()
+	defer h5unlock()
+
 	// ssize_t H5Fget_name(hid_t obj_id, char *name, size_t size )
 	sz := int(C.H5Fget_name(f.id, nil, 0)) + 1
 	if sz < 0 {
@@ -127,6 +205,9 @@ var cdot = C.CString(".")
 // Creates a packet table to store fixed-length packets. The returned
 // table must be closed by the user when it is no longer needed.
 func (f *File) CreateTable(name string, dtype *Datatype, chunkSize, compression int) (*Table, error) {
+	h5lock()
+	defer h5unlock()
+
 	// hid_t H5PTcreate_fl( hid_t loc_id, const char * dset_name, hid_t dtype_id, hsize_t chunk_size, int compression )
 	return createTable(f.id, name, dtype, chunkSize, compression)
 }
@@ -134,13 +215,48 @@ func (f *File) CreateTable(name string, dtype *Datatype, chunkSize, compression
 // Creates a packet table to store fixed-length packets. The returned
 // table must be closed by the user when it is no longer needed.
 func (f *File) CreateTableFrom(name string, dtype interface{}, chunkSize, compression int) (*Table, error) {
+	h5lock()
+	defer h5unlock()
+
 	// hid_t H5PTcreate_fl( hid_t loc_id, const char * dset_name, hid_t dtype_id, hsize_t chunk_size, int compression )
 	return createTableFrom(f.id, name, dtype, chunkSize, compression)
 }
 
+// CreateTableWith creates a packet table to store fixed-length packets,
+// with the dataset creation property list dcpl, to set the filters of the
+// table for instance. The chunk size of the table overrides the one of dcpl.
+// A nil dcpl stands for P_DEFAULT, without compression.
+// The returned table must be closed by the user when it is no longer needed.
+//
+// CreateTableWith requires HDF5 1.10 or later.
+func (f *File) CreateTableWith(name string, dtype *Datatype, chunkSize int, dcpl *PropList) (*Table, error) {
+	h5lock()
+	defer h5unlock()
+
+	return createTableWith(f.id, name, dtype, chunkSize, dcpl)
+}
+
+// CreateVarLenTable creates a packet table to store variable-length packets,
+// sequences of elements of datatype dtype such as T_NATIVE_UINT8 for []byte
+// packets. A compression level of -1 disables compression. Elements must
+// not hold Go pointers, such as strings. The returned table must be closed
+// by the user when it is no longer needed.
+//
+// HDF5 versions older than 1.10 only support packets of bytes, and do not
+// support compression: other compression levels than -1 are an error.
+func (f *File) CreateVarLenTable(name string, dtype *Datatype, chunkSize, compression int) (*Table, error) {
+	h5lock()
+	defer h5unlock()
+
+	return createVarLenTable(f.id, name, dtype, chunkSize, compression)
+}
+
 // Opens an existing packet table. The returned table must be closed
 // by the user when it is no longer needed.
 func (f *File) OpenTable(name string) (*Table, error) {
+	h5lock()
+	defer h5unlock()
+
 	// hid_t H5PTopen( hid_t loc_id, const char *dset_name )
 	return openTable(f.id, name)
 }
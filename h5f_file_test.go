@@ -141,3 +141,138 @@ func TestClosedFile(t *testing.T) {
 	}
 
 }
+
+func TestFileWith(t *testing.T) {
+	fcpl, err := NewPropList(P_FILE_CREATE)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fcpl.Close()
+	if err := fcpl.SetUserblock(1024); err != nil {
+		t.Fatalf("SetUserblock failed: %s", err)
+	}
+	if size, err := fcpl.Userblock(); err != nil || size != 1024 {
+		t.Fatalf("Userblock: got (%d, %v), want (1024, nil)", size, err)
+	}
+
+	fapl, err := NewPropList(P_FILE_ACCESS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fapl.Close()
+	if err := fapl.SetLibverBounds(F_LIBVER_LATEST, F_LIBVER_LATEST); err != nil {
+		t.Fatalf("SetLibverBounds failed: %s", err)
+	}
+	if low, high, err := fapl.LibverBounds(); err != nil || low != F_LIBVER_LATEST || high != F_LIBVER_LATEST {
+		t.Fatalf("LibverBounds: got (%d, %d, %v)", low, high, err)
+	}
+	if err := fapl.SetSieveBufSize(1 << 20); err != nil {
+		t.Fatalf("SetSieveBufSize failed: %s", err)
+	}
+	if size, err := fapl.SieveBufSize(); err != nil || size != 1<<20 {
+		t.Fatalf("SieveBufSize: got (%d, %v), want (%d, nil)", size, err, 1<<20)
+	}
+	if err := fapl.SetAlignment(4096, 512); err != nil {
+		t.Fatalf("SetAlignment failed: %s", err)
+	}
+	if threshold, alignment, err := fapl.Alignment(); err != nil || threshold != 4096 || alignment != 512 {
+		t.Fatalf("Alignment: got (%d, %d, %v)", threshold, alignment, err)
+	}
+	if err := fapl.SetMetadataCacheSize(4<<20, 1<<20, 32<<20); err != nil {
+		t.Fatalf("SetMetadataCacheSize failed: %s", err)
+	}
+	if initial, min, max, err := fapl.MetadataCacheSize(); err != nil || initial != 4<<20 || min != 1<<20 || max != 32<<20 {
+		t.Fatalf("MetadataCacheSize: got (%d, %d, %d, %v)", initial, min, max, err)
+	}
+
+	f, err := CreateFileWith(fname, F_ACC_TRUNC, fcpl, fapl)
+	if err != nil {
+		t.Fatalf("CreateFileWith failed: %s", err)
+	}
+	defer os.Remove(fname)
+	g, err := f.CreateGroup("foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.Close()
+	f.Close()
+
+	f, err = OpenFileWith(fname, F_ACC_RDONLY, fapl)
+	if err != nil {
+		t.Fatalf("OpenFileWith failed: %s", err)
+	}
+	defer f.Close()
+	if !f.LinkExists("foo") {
+		t.Fatalf("missing group in re-opened file")
+	}
+}
+
+func TestFileImage(t *testing.T) {
+	const name = "in-memory.h5"
+
+	fapl, err := NewPropList(P_FILE_ACCESS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fapl.Close()
+	if err := fapl.SetFaplCore(1<<16, false); err != nil {
+		t.Fatalf("SetFaplCore failed: %s", err)
+	}
+	if increment, backing, err := fapl.FaplCore(); err != nil || increment != 1<<16 || backing {
+		t.Fatalf("FaplCore: got (%d, %v, %v)", increment, backing, err)
+	}
+
+	f, err := CreateFileWith(name, F_ACC_TRUNC, P_DEFAULT, fapl)
+	if err != nil {
+		t.Fatalf("CreateFileWith failed: %s", err)
+	}
+	data := []int32{1, 2, 3, 4}
+	dims := []uint{uint(len(data))}
+	dspace, err := CreateSimpleDataspace(dims, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dspace.Close()
+	dset, err := f.CreateDataset("data", T_NATIVE_INT32, dspace)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dset.Write(&data); err != nil {
+		t.Fatal(err)
+	}
+	dset.Close()
+
+	image, err := f.Image()
+	if err != nil {
+		t.Fatalf("Image failed: %s", err)
+	}
+	f.Close()
+	if _, err := os.Stat(name); !os.IsNotExist(err) {
+		os.Remove(name)
+		t.Fatalf("core driver without backing store wrote to disk")
+	}
+
+	f, err = OpenFileImage(image, F_ACC_RDONLY)
+	if err != nil {
+		t.Fatalf("OpenFileImage failed: %s", err)
+	}
+	defer f.Close()
+	dset, err = f.OpenDataset("data")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dset.Close()
+	got := make([]int32, len(data))
+	if err := dset.Read(&got); err != nil {
+		t.Fatal(err)
+	}
+	for i := range data {
+		if got[i] != data[i] {
+			t.Fatalf("invalid data read from image: got=%v, want=%v", got, data)
+		}
+	}
+
+	if _, err := OpenFileImage(nil, F_ACC_RDONLY); err == nil {
+		t.Fatalf("expected an error opening an empty image")
+	}
+}
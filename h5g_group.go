@@ -60,6 +60,9 @@ type Group struct {
 // in the file. The returned group must be closed by the user when it is no
 // longer needed.
 func (g *CommonFG) CreateGroup(name string) (*Group, error) {
+	h5lock()
+	defer h5unlock()
+
 	c_name := C.CString(name)
 	defer C.free(unsafe.Pointer(c_name))
 
@@ -73,38 +76,124 @@ func (g *CommonFG) CreateGroup(name string) (*Group, error) {
 	return group, nil
 }
 
+// CreateGroupWith creates and returns a new empty group with a user-defined
+// group creation PropList, and links it to a location in the file.
+// The returned group must be closed by the user when it is no longer needed.
+func (g *CommonFG) CreateGroupWith(name string, gcpl *PropList) (*Group, error) {
+	h5lock()
+	defer h5unlock()
+
+	c_name := C.CString(name)
+	defer C.free(unsafe.Pointer(c_name))
+
+	hid := C.H5Gcreate2(g.id, c_name, P_DEFAULT.id, gcpl.id, P_DEFAULT.id)
+	if err := checkID(hid); err != nil {
+		return nil, err
+	}
+	group := &Group{CommonFG{Identifier{hid}}}
+	return group, nil
+}
+
 // CreateDataset creates a new Dataset. The returned dataset must be
 // closed by the user when it is no longer needed.
 func (g *CommonFG) CreateDataset(name string, dtype *Datatype, dspace *Dataspace) (*Dataset, error) {
+	h5lock()
+	defer h5unlock()
+
 	return createDataset(g.id, name, dtype, dspace, P_DEFAULT)
 }
 
 // CreateDatasetWith creates a new Dataset with a user-defined PropList.
 // The returned dataset must be closed by the user when it is no longer needed.
 func (g *CommonFG) CreateDatasetWith(name string, dtype *Datatype, dspace *Dataspace, dcpl *PropList) (*Dataset, error) {
+	h5lock()
+	defer h5unlock()
+
 	return createDataset(g.id, name, dtype, dspace, dcpl)
 }
 
 // CreateAttribute creates a new attribute at this location. The returned
 // attribute must be closed by the user when it is no longer needed.
-func (g *Group) CreateAttribute(name string, dtype *Datatype, dspace *Dataspace) (*Attribute, error) {
+func (g *CommonFG) CreateAttribute(name string, dtype *Datatype, dspace *Dataspace) (*Attribute, error) {
+	h5lock()
+	defer h5unlock()
+
 	return createAttribute(g.id, name, dtype, dspace, P_DEFAULT)
 }
 
 // CreateAttributeWith creates a new attribute at this location with a user-defined
 // PropList. The returned dataset must be closed by the user when it is no longer needed.
-func (g *Group) CreateAttributeWith(name string, dtype *Datatype, dspace *Dataspace, acpl *PropList) (*Attribute, error) {
+func (g *CommonFG) CreateAttributeWith(name string, dtype *Datatype, dspace *Dataspace, acpl *PropList) (*Attribute, error) {
+	h5lock()
+	defer h5unlock()
+
 	return createAttribute(g.id, name, dtype, dspace, acpl)
 }
 
 // Opens an existing attribute. The returned attribute must be closed
 // by the user when it is no longer needed.
-func (g *Group) OpenAttribute(name string) (*Attribute, error) {
+func (g *CommonFG) OpenAttribute(name string) (*Attribute, error) {
+	h5lock()
+	defer h5unlock()
+
 	return openAttribute(g.id, name)
 }
 
+// NumAttributes returns the number of attributes attached at this location.
+func (g *CommonFG) NumAttributes() (int, error) {
+	h5lock()
+	defer h5unlock()
+
+	return numAttributes(g.id)
+}
+
+// AttributeNames returns the names of the attributes attached at this location.
+func (g *CommonFG) AttributeNames() ([]string, error) {
+	h5lock()
+	defer h5unlock()
+
+	return attributeNames(g.id)
+}
+
+// AttributeExists returns whether an attribute with the specified name
+// is attached at this location.
+func (g *CommonFG) AttributeExists(name string) bool {
+	h5lock()
+	defer h5unlock()
+
+	return attributeExists(g.id, name)
+}
+
+// DeleteAttribute removes the named attribute from this location.
+func (g *CommonFG) DeleteAttribute(name string) error {
+	h5lock()
+	defer h5unlock()
+
+	return deleteAttribute(g.id, name)
+}
+
+// RenameAttribute renames the attribute oldName attached at this location to newName.
+func (g *CommonFG) RenameAttribute(oldName, newName string) error {
+	h5lock()
+	defer h5unlock()
+
+	return renameAttribute(g.id, oldName, newName)
+}
+
+// IterateAttributes calls fn for each attribute attached at this location,
+// in increasing name order. The iteration stops at the first error returned by fn.
+func (g *CommonFG) IterateAttributes(fn AttributeFunc) error {
+	h5lock()
+	defer h5unlock()
+
+	return iterateAttributes(g.id, fn)
+}
+
 // Close closes the Group.
 func (g *Group) Close() error {
+	h5lock()
+	defer h5unlock()
+
 	return g.closeWith(h5gclose)
 }
 
@@ -115,6 +204,9 @@ func h5gclose(id C.hid_t) C.herr_t {
 // OpenGroup opens and returns an existing child group from this Group.
 // The returned group must be closed by the user when it is no longer needed.
 func (g *CommonFG) OpenGroup(name string) (*Group, error) {
+	h5lock()
+	defer h5unlock()
+
 	c_name := C.CString(name)
 	defer C.free(unsafe.Pointer(c_name))
 
@@ -129,6 +221,9 @@ func (g *CommonFG) OpenGroup(name string) (*Group, error) {
 // OpenDataset opens and returns a named Dataset. The returned
 // dataset must be closed by the user when it is no longer needed.
 func (g *CommonFG) OpenDataset(name string) (*Dataset, error) {
+	h5lock()
+	defer h5unlock()
+
 	c_name := C.CString(name)
 	defer C.free(unsafe.Pointer(c_name))
 
@@ -142,6 +237,9 @@ func (g *CommonFG) OpenDataset(name string) (*Dataset, error) {
 // OpenDatasetWith opens and returns a named Dataset with a user-defined PropList.
 // The returned dataset must be closed by the user when it is no longer needed.
 func (g *CommonFG) OpenDatasetWith(name string, dapl *PropList) (*Dataset, error) {
+	h5lock()
+	defer h5unlock()
+
 	c_name := C.CString(name)
 	defer C.free(unsafe.Pointer(c_name))
 
@@ -154,6 +252,9 @@ func (g *CommonFG) OpenDatasetWith(name string, dapl *PropList) (*Dataset, error
 
 // NumObjects returns the number of objects in the Group.
 func (g *CommonFG) NumObjects() (uint, error) {
+	h5lock()
+	defer h5unlock()
+
 	var info C.H5G_info_t
 	err := h5err(C.H5Gget_info(g.id, &info))
 	return uint(info.nlinks), err
@@ -161,6 +262,9 @@ func (g *CommonFG) NumObjects() (uint, error) {
 
 // ObjectNameByIndex returns the name of the object at idx.
 func (g *CommonFG) ObjectNameByIndex(idx uint) (string, error) {
+	h5lock()
+	defer h5unlock()
+
 	cidx := C.hsize_t(idx)
 	size := C.H5Lget_name_by_idx(g.id, cdot, C.H5_INDEX_NAME, C.H5_ITER_INC, cidx, nil, 0, C.H5P_DEFAULT)
 	if size < 0 {
@@ -178,6 +282,9 @@ func (g *CommonFG) ObjectNameByIndex(idx uint) (string, error) {
 
 // ObjectTypeByIndex returns the type of the object at idx.
 func (g *CommonFG) ObjectTypeByIndex(idx uint) (GType, error) {
+	h5lock()
+	defer h5unlock()
+
 	cidx := C.hsize_t(idx)
 	gtyp := GType(C.H5Gget_objtype_by_idx(g.id, cidx))
 	if gtyp < H5G_GROUP {
@@ -190,23 +297,55 @@ func (g *CommonFG) ObjectTypeByIndex(idx uint) (GType, error) {
 // CreateTable creates a packet table to store fixed-length packets.
 // The returned table must be closed by the user when it is no longer needed.
 func (g *Group) CreateTable(name string, dtype *Datatype, chunkSize, compression int) (*Table, error) {
+	h5lock()
+	defer h5unlock()
+
 	return createTable(g.id, name, dtype, chunkSize, compression)
 }
 
 // CreateTableFrom creates a packet table to store fixed-length packets.
 // The returned table must be closed by the user when it is no longer needed.
 func (g *Group) CreateTableFrom(name string, dtype interface{}, chunkSize, compression int) (*Table, error) {
+	h5lock()
+	defer h5unlock()
+
 	return createTableFrom(g.id, name, dtype, chunkSize, compression)
 }
 
+// CreateTableWith creates a packet table to store fixed-length packets with
+// the dataset creation property list dcpl, as File.CreateTableWith does.
+// The returned table must be closed by the user when it is no longer needed.
+func (g *Group) CreateTableWith(name string, dtype *Datatype, chunkSize int, dcpl *PropList) (*Table, error) {
+	h5lock()
+	defer h5unlock()
+
+	return createTableWith(g.id, name, dtype, chunkSize, dcpl)
+}
+
+// CreateVarLenTable creates a packet table to store variable-length packets,
+// as File.CreateVarLenTable does.
+// The returned table must be closed by the user when it is no longer needed.
+func (g *Group) CreateVarLenTable(name string, dtype *Datatype, chunkSize, compression int) (*Table, error) {
+	h5lock()
+	defer h5unlock()
+
+	return createVarLenTable(g.id, name, dtype, chunkSize, compression)
+}
+
 // OpenTable opens an existing packet table. The returned table must be
 // closed by the user when it is no longer needed.
 func (g *Group) OpenTable(name string) (*Table, error) {
+	h5lock()
+	defer h5unlock()
+
 	return openTable(g.id, name)
 }
 
 // LinkExists returns whether a link with the specified name exists in the group.
 func (g *CommonFG) LinkExists(name string) bool {
+	h5lock()
+	defer h5unlock()
+
 	c_name := C.CString(name)
 	defer C.free(unsafe.Pointer(c_name))
 	return C.H5Lexists(g.id, c_name, 0) > 0
@@ -61,6 +61,9 @@ func (i Identifier) ID() int64 {
 
 // Name returns the full name of the Identifier
 func (i Identifier) Name() string {
+	h5lock()
+	defer h5unlock()
+
 	sz := int(C.H5Iget_name(i.id, nil, 0)) + 1
 	if sz < 0 {
 		return ""
@@ -77,6 +80,9 @@ func (i Identifier) Name() string {
 
 // File returns the file associated with this Identifier.
 func (i Identifier) File() *File {
+	h5lock()
+	defer h5unlock()
+
 	fid := C.H5Iget_file_id(i.id)
 	if fid < 0 {
 		return nil
@@ -86,6 +92,9 @@ func (i Identifier) File() *File {
 
 // Type returns the type of the identifier.
 func (i Identifier) Type() IType {
+	h5lock()
+	defer h5unlock()
+
 	return IType(C.H5Iget_type(i.id))
 }
 
@@ -0,0 +1,182 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hdf5
+
+// #include "hdf5.h"
+// #include <stdlib.h>
+// #include <string.h>
+// static inline size_t _go_hdf5_linfo_val_size(H5L_info_t *info) { return info->u.val_size; }
+import "C"
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// LinkType describes the kind of a link inside a Group or File.
+type LinkType C.H5L_type_t
+
+const (
+	L_TYPE_ERROR    LinkType = C.H5L_TYPE_ERROR    // Invalid link type
+	L_TYPE_HARD     LinkType = C.H5L_TYPE_HARD     // Hard link
+	L_TYPE_SOFT     LinkType = C.H5L_TYPE_SOFT     // Soft link
+	L_TYPE_EXTERNAL LinkType = C.H5L_TYPE_EXTERNAL // External link
+)
+
+func (typ LinkType) String() string {
+	switch typ {
+	case L_TYPE_HARD:
+		return "hard"
+	case L_TYPE_SOFT:
+		return "soft"
+	case L_TYPE_EXTERNAL:
+		return "external"
+	case L_TYPE_ERROR:
+		return "error"
+	default:
+		return fmt.Sprintf("LinkType(%d)", int(typ))
+	}
+}
+
+// LinkInfo describes a link.
+type LinkInfo struct {
+	Type LinkType
+
+	// Target is the path the link points to, for soft and external links.
+	Target string
+
+	// File is the name of the file the link points into, for external links.
+	File string
+}
+
+// CreateSoftLink creates a soft link named name pointing to target.
+// The target does not need to exist at the time the link is created.
+func (g *CommonFG) CreateSoftLink(target, name string) error {
+	h5lock()
+	defer h5unlock()
+
+	c_target := C.CString(target)
+	defer C.free(unsafe.Pointer(c_target))
+	c_name := C.CString(name)
+	defer C.free(unsafe.Pointer(c_name))
+
+	return h5err(C.H5Lcreate_soft(c_target, g.id, c_name, P_DEFAULT.id, P_DEFAULT.id))
+}
+
+// CreateHardLink creates a hard link named name to the existing object target.
+func (g *CommonFG) CreateHardLink(target, name string) error {
+	h5lock()
+	defer h5unlock()
+
+	c_target := C.CString(target)
+	defer C.free(unsafe.Pointer(c_target))
+	c_name := C.CString(name)
+	defer C.free(unsafe.Pointer(c_name))
+
+	return h5err(C.H5Lcreate_hard(g.id, c_target, g.id, c_name, P_DEFAULT.id, P_DEFAULT.id))
+}
+
+// CreateExternalLink creates a link named name pointing to the object at
+// path inside the HDF5 file fname.
+// Neither the file nor the object need to exist at the time the link is created.
+func (g *CommonFG) CreateExternalLink(fname, path, name string) error {
+	h5lock()
+	defer h5unlock()
+
+	c_fname := C.CString(fname)
+	defer C.free(unsafe.Pointer(c_fname))
+	c_path := C.CString(path)
+	defer C.free(unsafe.Pointer(c_path))
+	c_name := C.CString(name)
+	defer C.free(unsafe.Pointer(c_name))
+
+	return h5err(C.H5Lcreate_external(c_fname, c_path, g.id, c_name, P_DEFAULT.id, P_DEFAULT.id))
+}
+
+// DeleteLink removes the link name. The object it pointed to is removed from
+// the file when its last hard link is deleted.
+func (g *CommonFG) DeleteLink(name string) error {
+	h5lock()
+	defer h5unlock()
+
+	c_name := C.CString(name)
+	defer C.free(unsafe.Pointer(c_name))
+
+	return h5err(C.H5Ldelete(g.id, c_name, P_DEFAULT.id))
+}
+
+// MoveLink renames the link src to dst.
+func (g *CommonFG) MoveLink(src, dst string) error {
+	h5lock()
+	defer h5unlock()
+
+	c_src := C.CString(src)
+	defer C.free(unsafe.Pointer(c_src))
+	c_dst := C.CString(dst)
+	defer C.free(unsafe.Pointer(c_dst))
+
+	return h5err(C.H5Lmove(g.id, c_src, g.id, c_dst, P_DEFAULT.id, P_DEFAULT.id))
+}
+
+// CopyLink creates dst as a copy of the link src.
+// Only the link is copied, not the object it points to.
+func (g *CommonFG) CopyLink(src, dst string) error {
+	h5lock()
+	defer h5unlock()
+
+	c_src := C.CString(src)
+	defer C.free(unsafe.Pointer(c_src))
+	c_dst := C.CString(dst)
+	defer C.free(unsafe.Pointer(c_dst))
+
+	return h5err(C.H5Lcopy(g.id, c_src, g.id, c_dst, P_DEFAULT.id, P_DEFAULT.id))
+}
+
+// LinkInfo returns the type of the link name and, for soft and
+// external links, the target it points to.
+func (g *CommonFG) LinkInfo(name string) (LinkInfo, error) {
+	h5lock()
+	defer h5unlock()
+
+	c_name := C.CString(name)
+	defer C.free(unsafe.Pointer(c_name))
+
+	var info C.H5L_info_t
+	if err := h5err(C.H5Lget_info(g.id, c_name, &info, P_DEFAULT.id)); err != nil {
+		return LinkInfo{Type: L_TYPE_ERROR}, err
+	}
+
+	linfo := LinkInfo{Type: LinkType(info._type)}
+	if linfo.Type == L_TYPE_HARD {
+		return linfo, nil
+	}
+
+	size := C._go_hdf5_linfo_val_size(&info)
+	if size == 0 {
+		return linfo, nil
+	}
+	buf := C.malloc(size)
+	defer C.free(buf)
+	if err := h5err(C.H5Lget_val(g.id, c_name, buf, size, P_DEFAULT.id)); err != nil {
+		return linfo, err
+	}
+
+	switch linfo.Type {
+	case L_TYPE_SOFT:
+		linfo.Target = C.GoString((*C.char)(buf))
+	case L_TYPE_EXTERNAL:
+		var (
+			flags  C.uint
+			c_file *C.char
+			c_path *C.char
+		)
+		if err := h5err(C.H5Lunpack_elink_val(buf, size, &flags, &c_file, &c_path)); err != nil {
+			return linfo, err
+		}
+		linfo.File = C.GoString(c_file)
+		linfo.Target = C.GoString(c_path)
+	}
+	return linfo, nil
+}
@@ -0,0 +1,110 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hdf5
+
+import (
+	"os"
+	"testing"
+)
+
+func TestLinks(t *testing.T) {
+	f, err := CreateFile(fname, F_ACC_TRUNC)
+	if err != nil {
+		t.Fatalf("CreateFile failed: %s", err)
+	}
+	defer os.Remove(fname)
+	defer f.Close()
+
+	g, err := f.CreateGroup("foo")
+	if err != nil {
+		t.Fatalf("couldn't create group: %s", err)
+	}
+	defer g.Close()
+
+	if err := f.CreateHardLink("foo", "hard"); err != nil {
+		t.Fatalf("could not create hard link: %v", err)
+	}
+	if err := f.CreateSoftLink("/foo", "soft"); err != nil {
+		t.Fatalf("could not create soft link: %v", err)
+	}
+	if err := f.CreateSoftLink("/dangling", "dangling"); err != nil {
+		t.Fatalf("could not create dangling soft link: %v", err)
+	}
+	if err := g.CreateExternalLink("other.h5", "/bar", "ext"); err != nil {
+		t.Fatalf("could not create external link: %v", err)
+	}
+	if err := f.CreateHardLink("not-there", "hard2"); err == nil {
+		t.Fatalf("expected an error creating a hard link to a missing object")
+	}
+
+	for _, tc := range []struct {
+		name string
+		want LinkInfo
+	}{
+		{"foo", LinkInfo{Type: L_TYPE_HARD}},
+		{"hard", LinkInfo{Type: L_TYPE_HARD}},
+		{"soft", LinkInfo{Type: L_TYPE_SOFT, Target: "/foo"}},
+		{"dangling", LinkInfo{Type: L_TYPE_SOFT, Target: "/dangling"}},
+		{"/foo/ext", LinkInfo{Type: L_TYPE_EXTERNAL, Target: "/bar", File: "other.h5"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := f.LinkInfo(tc.name)
+			if err != nil {
+				t.Fatalf("could not get link info: %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("invalid link info:\ngot= %#v\nwant=%#v", got, tc.want)
+			}
+		})
+	}
+
+	if _, err := f.LinkInfo("not-there"); err == nil {
+		t.Fatalf("expected an error for a missing link")
+	}
+
+	// the soft link resolves to the group.
+	sg, err := f.OpenGroup("soft")
+	if err != nil {
+		t.Fatalf("could not open group through soft link: %v", err)
+	}
+	sg.Close()
+
+	if err := f.MoveLink("hard", "moved"); err != nil {
+		t.Fatalf("could not move link: %v", err)
+	}
+	if f.LinkExists("hard") || !f.LinkExists("moved") {
+		t.Fatalf("link was not moved")
+	}
+
+	if err := f.CopyLink("soft", "/foo/soft-copy"); err != nil {
+		t.Fatalf("could not copy link: %v", err)
+	}
+	if !f.LinkExists("soft") {
+		t.Fatalf("copied link was removed")
+	}
+	if got, err := g.LinkInfo("soft-copy"); err != nil {
+		t.Fatalf("could not get link info: %v", err)
+	} else if got.Type != L_TYPE_SOFT || got.Target != "/foo" {
+		t.Fatalf("invalid copied link info: %#v", got)
+	}
+
+	for _, name := range []string{"moved", "soft", "dangling"} {
+		if err := f.DeleteLink(name); err != nil {
+			t.Fatalf("could not delete link %q: %v", name, err)
+		}
+		if f.LinkExists(name) {
+			t.Fatalf("link %q still exists", name)
+		}
+	}
+	if err := f.DeleteLink("not-there"); err == nil {
+		t.Fatalf("expected an error deleting a missing link")
+	}
+
+	if n, err := f.NumObjects(); err != nil {
+		t.Fatal(err)
+	} else if n != 1 {
+		t.Fatalf("invalid number of links: got=%d, want=%d", n, 1)
+	}
+}
@@ -0,0 +1,502 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hdf5
+
+// #include "hdf5.h"
+// #include "hdf5_hl.h"
+// #include <stdlib.h>
+//
+// enum {
+//   _GO_HDF5_LT_CHAR,
+//   _GO_HDF5_LT_UCHAR,
+//   _GO_HDF5_LT_SHORT,
+//   _GO_HDF5_LT_USHORT,
+//   _GO_HDF5_LT_INT,
+//   _GO_HDF5_LT_UINT,
+//   _GO_HDF5_LT_LLONG,
+//   _GO_HDF5_LT_ULLONG,
+//   _GO_HDF5_LT_FLOAT,
+//   _GO_HDF5_LT_DOUBLE,
+// };
+//
+// /* H5LT has no unsigned long long attribute functions, and unsigned long is
+//    32 bits wide on LLP64 platforms. */
+// static inline herr_t _go_hdf5_lt_set_attribute_ullong(hid_t loc, const char *obj, const char *attr, const void *buf, size_t n) {
+//   hid_t oid, sid, aid;
+//   hsize_t dim = n;
+//   htri_t exists;
+//   herr_t ret = -1;
+//   if ((oid = H5Oopen(loc, obj, H5P_DEFAULT)) < 0) {
+//     return -1;
+//   }
+//   if ((exists = H5Aexists(oid, attr)) < 0 || (exists > 0 && H5Adelete(oid, attr) < 0)) {
+//     H5Oclose(oid);
+//     return -1;
+//   }
+//   if ((sid = H5Screate_simple(1, &dim, NULL)) < 0) {
+//     H5Oclose(oid);
+//     return -1;
+//   }
+//   if ((aid = H5Acreate2(oid, attr, H5T_NATIVE_ULLONG, sid, H5P_DEFAULT, H5P_DEFAULT)) >= 0) {
+//     ret = H5Awrite(aid, H5T_NATIVE_ULLONG, buf);
+//     H5Aclose(aid);
+//   }
+//   H5Sclose(sid);
+//   H5Oclose(oid);
+//   return ret;
+// }
+//
+// static inline herr_t _go_hdf5_lt_get_attribute_ullong(hid_t loc, const char *obj, const char *attr, void *buf) {
+//   hid_t aid;
+//   herr_t ret;
+//   if ((aid = H5Aopen_by_name(loc, obj, attr, H5P_DEFAULT, H5P_DEFAULT)) < 0) {
+//     return -1;
+//   }
+//   ret = H5Aread(aid, H5T_NATIVE_ULLONG, buf);
+//   H5Aclose(aid);
+//   return ret;
+// }
+//
+// static inline herr_t _go_hdf5_lt_set_attribute(hid_t loc, const char *obj, const char *attr, int kind, const void *buf, size_t n) {
+//   switch (kind) {
+//   case _GO_HDF5_LT_CHAR:   return H5LTset_attribute_char(loc, obj, attr, buf, n);
+//   case _GO_HDF5_LT_UCHAR:  return H5LTset_attribute_uchar(loc, obj, attr, buf, n);
+//   case _GO_HDF5_LT_SHORT:  return H5LTset_attribute_short(loc, obj, attr, buf, n);
+//   case _GO_HDF5_LT_USHORT: return H5LTset_attribute_ushort(loc, obj, attr, buf, n);
+//   case _GO_HDF5_LT_INT:    return H5LTset_attribute_int(loc, obj, attr, buf, n);
+//   case _GO_HDF5_LT_UINT:   return H5LTset_attribute_uint(loc, obj, attr, buf, n);
+//   case _GO_HDF5_LT_LLONG:  return H5LTset_attribute_long_long(loc, obj, attr, buf, n);
+//   case _GO_HDF5_LT_ULLONG: return _go_hdf5_lt_set_attribute_ullong(loc, obj, attr, buf, n);
+//   case _GO_HDF5_LT_FLOAT:  return H5LTset_attribute_float(loc, obj, attr, buf, n);
+//   case _GO_HDF5_LT_DOUBLE: return H5LTset_attribute_double(loc, obj, attr, buf, n);
+//   }
+//   return -1;
+// }
+//
+// static inline herr_t _go_hdf5_lt_get_attribute(hid_t loc, const char *obj, const char *attr, int kind, void *buf) {
+//   switch (kind) {
+//   case _GO_HDF5_LT_CHAR:   return H5LTget_attribute_char(loc, obj, attr, buf);
+//   case _GO_HDF5_LT_UCHAR:  return H5LTget_attribute_uchar(loc, obj, attr, buf);
+//   case _GO_HDF5_LT_SHORT:  return H5LTget_attribute_short(loc, obj, attr, buf);
+//   case _GO_HDF5_LT_USHORT: return H5LTget_attribute_ushort(loc, obj, attr, buf);
+//   case _GO_HDF5_LT_INT:    return H5LTget_attribute_int(loc, obj, attr, buf);
+//   case _GO_HDF5_LT_UINT:   return H5LTget_attribute_uint(loc, obj, attr, buf);
+//   case _GO_HDF5_LT_LLONG:  return H5LTget_attribute_long_long(loc, obj, attr, buf);
+//   case _GO_HDF5_LT_ULLONG: return _go_hdf5_lt_get_attribute_ullong(loc, obj, attr, buf);
+//   case _GO_HDF5_LT_FLOAT:  return H5LTget_attribute_float(loc, obj, attr, buf);
+//   case _GO_HDF5_LT_DOUBLE: return H5LTget_attribute_double(loc, obj, attr, buf);
+//   }
+//   return -1;
+// }
+//
+// static inline htri_t _go_hdf5_lt_attribute_is_vlstr(hid_t loc, const char *obj, const char *attr) {
+//   hid_t aid, tid;
+//   htri_t ret;
+//   if ((aid = H5Aopen_by_name(loc, obj, attr, H5P_DEFAULT, H5P_DEFAULT)) < 0) {
+//     return -1;
+//   }
+//   if ((tid = H5Aget_type(aid)) < 0) {
+//     H5Aclose(aid);
+//     return -1;
+//   }
+//   ret = H5Tis_variable_str(tid);
+//   H5Tclose(tid);
+//   H5Aclose(aid);
+//   return ret;
+// }
+import "C"
+
+import (
+	"fmt"
+	"reflect"
+	"unsafe"
+)
+
+// DataInfo describes the extent and the element datatype of a dataset or
+// of an attribute.
+type DataInfo struct {
+	Dims  []uint    // Dimensions of the dataspace
+	Class TypeClass // Class of the datatype
+	Size  uint      // Size of the datatype, in bytes
+}
+
+// MakeDataset creates the dataset name with dimensions dims and writes data
+// to it in a single call. data must be a slice or an array, or a pointer to
+// one, whose number of elements matches dims. The datatype of the dataset is
+// built from the element type of data with NewDataTypeFromType, except for
+// int and uint elements, which are stored with the integer datatype of their
+// size.
+func (g *CommonFG) MakeDataset(name string, dims []uint, data interface{}) error {
+	h5lock()
+	defer h5unlock()
+
+	buf, err := newLiteBuffer(data, false)
+	if err != nil {
+		return err
+	}
+	n := 1
+	for _, d := range dims {
+		n *= int(d)
+	}
+	if n != buf.n {
+		return fmt.Errorf("hdf5: number of elements mismatch (got %d, want %d)", buf.n, n)
+	}
+	dtype, err := liteDatatype(buf.elem)
+	if err != nil {
+		return err
+	}
+	defer dtype.Close()
+
+	c_name := C.CString(name)
+	defer C.free(unsafe.Pointer(c_name))
+	var c_dims *C.hsize_t
+	if len(dims) > 0 {
+		hdims := make([]C.hsize_t, len(dims))
+		for i, d := range dims {
+			hdims[i] = C.hsize_t(d)
+		}
+		c_dims = &hdims[0]
+	}
+	return h5err(C.H5LTmake_dataset(g.id, c_name, C.int(len(dims)), c_dims, dtype.id, buf.addr))
+}
+
+// ReadDataset reads the whole content of the dataset name into data, a
+// slice or a pointer to an array or a slice, in a single call. data must be
+// large enough to hold all the elements of the dataset. The memory datatype
+// is built from the element type of data as MakeDataset does.
+func (g *CommonFG) ReadDataset(name string, data interface{}) error {
+	h5lock()
+	defer h5unlock()
+
+	buf, err := newLiteBuffer(data, true)
+	if err != nil {
+		return err
+	}
+	info, err := g.DatasetInfo(name)
+	if err != nil {
+		return err
+	}
+	if n := info.numElements(); buf.n < n {
+		return fmt.Errorf("hdf5: buffer too small for dataset %q (got %d elements, want %d)", name, buf.n, n)
+	}
+	mtype, err := liteDatatype(buf.elem)
+	if err != nil {
+		return err
+	}
+	defer mtype.Close()
+
+	c_name := C.CString(name)
+	defer C.free(unsafe.Pointer(c_name))
+	return h5err(C.H5LTread_dataset(g.id, c_name, mtype.id, buf.addr))
+}
+
+// DatasetInfo returns the dimensions and the datatype description of the
+// dataset name.
+func (g *CommonFG) DatasetInfo(name string) (DataInfo, error) {
+	h5lock()
+	defer h5unlock()
+
+	c_name := C.CString(name)
+	defer C.free(unsafe.Pointer(c_name))
+
+	var rank C.int
+	if err := h5err(C.H5LTget_dataset_ndims(g.id, c_name, &rank)); err != nil {
+		return DataInfo{}, err
+	}
+	dims := make([]C.hsize_t, rank+1) // Avoid taking the address of an empty slice.
+	var (
+		class C.H5T_class_t
+		size  C.size_t
+	)
+	if err := h5err(C.H5LTget_dataset_info(g.id, c_name, &dims[0], &class, &size)); err != nil {
+		return DataInfo{}, err
+	}
+	return newDataInfo(dims[:rank], class, size), nil
+}
+
+// SetAttribute creates or overwrites the attribute attrName of the object
+// objName, relative to g, with data in a single call. data must be a string,
+// a number, or a slice or an array of numbers. Numbers must have a fixed
+// size: int and uint are not supported.
+func (g *CommonFG) SetAttribute(objName, attrName string, data interface{}) error {
+	h5lock()
+	defer h5unlock()
+
+	c_obj := C.CString(objName)
+	defer C.free(unsafe.Pointer(c_obj))
+	c_attr := C.CString(attrName)
+	defer C.free(unsafe.Pointer(c_attr))
+
+	v := reflect.Indirect(reflect.ValueOf(data))
+	if v.Kind() == reflect.String {
+		c_str := C.CString(v.String())
+		defer C.free(unsafe.Pointer(c_str))
+		return h5err(C.H5LTset_attribute_string(g.id, c_obj, c_attr, c_str))
+	}
+
+	if k := v.Kind(); k != reflect.Slice && k != reflect.Array {
+		// Store scalars as a single element array.
+		a := reflect.New(reflect.ArrayOf(1, v.Type())).Elem()
+		a.Index(0).Set(v)
+		v = a
+	}
+	buf, err := newLiteBuffer(v.Interface(), false)
+	if err != nil {
+		return err
+	}
+	kind, err := liteKind(buf.elem)
+	if err != nil {
+		return err
+	}
+	return h5err(C._go_hdf5_lt_set_attribute(g.id, c_obj, c_attr, kind, buf.addr, C.size_t(buf.n)))
+}
+
+// ReadAttribute reads the attribute attrName of the object objName, relative
+// to g, into data in a single call. data must be a pointer to a string or to
+// a number, a slice of numbers, or a pointer to a slice or an array of numbers.
+// Numbers must have a fixed size: int and uint are not supported. Only
+// fixed-length string attributes, such as those written by SetAttribute,
+// can be read into a string.
+func (g *CommonFG) ReadAttribute(objName, attrName string, data interface{}) error {
+	h5lock()
+	defer h5unlock()
+
+	info, err := g.AttributeDataInfo(objName, attrName)
+	if err != nil {
+		return err
+	}
+
+	c_obj := C.CString(objName)
+	defer C.free(unsafe.Pointer(c_obj))
+	c_attr := C.CString(attrName)
+	defer C.free(unsafe.Pointer(c_attr))
+
+	rv := reflect.ValueOf(data)
+	if rv.Kind() == reflect.Ptr && rv.Elem().Kind() == reflect.String {
+		vlen := C._go_hdf5_lt_attribute_is_vlstr(g.id, c_obj, c_attr)
+		if err := h5err(C.herr_t(vlen)); err != nil {
+			return err
+		}
+		if vlen > 0 {
+			return fmt.Errorf("hdf5: can not read variable-length string attribute %q", attrName)
+		}
+		if info.numElements() != 1 {
+			return fmt.Errorf("hdf5: can not read %d strings into a string", info.numElements())
+		}
+		c_str := (*C.char)(C.calloc(C.size_t(info.Size+1), 1))
+		if c_str == nil {
+			return fmt.Errorf("hdf5: could not allocate memory for attribute %q", attrName)
+		}
+		defer C.free(unsafe.Pointer(c_str))
+		if err := h5err(C.H5LTget_attribute_string(g.id, c_obj, c_attr, c_str)); err != nil {
+			return err
+		}
+		rv.Elem().SetString(C.GoString(c_str))
+		return nil
+	}
+
+	if rv.Kind() == reflect.Ptr {
+		if k := rv.Elem().Kind(); k != reflect.Slice && k != reflect.Array {
+			// Read scalars through a single element array view.
+			rv = reflect.NewAt(reflect.ArrayOf(1, rv.Type().Elem()), unsafe.Pointer(rv.Pointer()))
+		}
+	}
+	buf, err := newLiteBuffer(rv.Interface(), true)
+	if err != nil {
+		return err
+	}
+	if n := info.numElements(); buf.n < n {
+		return fmt.Errorf("hdf5: buffer too small for attribute %q (got %d elements, want %d)", attrName, buf.n, n)
+	}
+	kind, err := liteKind(buf.elem)
+	if err != nil {
+		return err
+	}
+	return h5err(C._go_hdf5_lt_get_attribute(g.id, c_obj, c_attr, kind, buf.addr))
+}
+
+// AttributeDataInfo returns the dimensions and the datatype description of
+// the attribute attrName of the object objName, relative to g.
+func (g *CommonFG) AttributeDataInfo(objName, attrName string) (DataInfo, error) {
+	h5lock()
+	defer h5unlock()
+
+	c_obj := C.CString(objName)
+	defer C.free(unsafe.Pointer(c_obj))
+	c_attr := C.CString(attrName)
+	defer C.free(unsafe.Pointer(c_attr))
+
+	var rank C.int
+	if err := h5err(C.H5LTget_attribute_ndims(g.id, c_obj, c_attr, &rank)); err != nil {
+		return DataInfo{}, err
+	}
+	dims := make([]C.hsize_t, rank+1) // Avoid taking the address of an empty slice.
+	var (
+		class C.H5T_class_t
+		size  C.size_t
+	)
+	if err := h5err(C.H5LTget_attribute_info(g.id, c_obj, c_attr, &dims[0], &class, &size)); err != nil {
+		return DataInfo{}, err
+	}
+	return newDataInfo(dims[:rank], class, size), nil
+}
+
+// ParseDatatype creates a datatype from its description in the HDF5 DDL
+// text format, such as "H5T_STD_I32LE".
+// The returned datatype must be closed by the user when it is no longer needed.
+func ParseDatatype(text string) (*Datatype, error) {
+	h5lock()
+	defer h5unlock()
+
+	c_text := C.CString(text)
+	defer C.free(unsafe.Pointer(c_text))
+
+	hid := C.H5LTtext_to_dtype(c_text, C.H5LT_DDL)
+	if err := checkID(hid); err != nil {
+		return nil, fmt.Errorf("hdf5: could not parse datatype %q: %w", text, err)
+	}
+	return NewDatatype(hid), nil
+}
+
+// DDL returns the description of the datatype in the HDF5 DDL text format.
+// The description can be parsed back with ParseDatatype.
+func (t *Datatype) DDL() (string, error) {
+	h5lock()
+	defer h5unlock()
+
+	var n C.size_t
+	if err := h5err(C.H5LTdtype_to_text(t.id, nil, C.H5LT_DDL, &n)); err != nil {
+		return "", err
+	}
+	buf := make([]C.char, n+1)
+	n = C.size_t(len(buf))
+	if err := h5err(C.H5LTdtype_to_text(t.id, &buf[0], C.H5LT_DDL, &n)); err != nil {
+		return "", err
+	}
+	return C.GoString(&buf[0]), nil
+}
+
+func newDataInfo(dims []C.hsize_t, class C.H5T_class_t, size C.size_t) DataInfo {
+	info := DataInfo{
+		Dims:  make([]uint, len(dims)),
+		Class: TypeClass(class),
+		Size:  uint(size),
+	}
+	for i, d := range dims {
+		info.Dims[i] = uint(d)
+	}
+	return info
+}
+
+func (info DataInfo) numElements() int {
+	n := 1
+	for _, d := range info.Dims {
+		n *= int(d)
+	}
+	return n
+}
+
+// liteBuffer describes a Go buffer exchanged with the H5LT functions.
+type liteBuffer struct {
+	addr unsafe.Pointer // address of the first element, nil if empty
+	elem reflect.Type   // type of the elements
+	n    int            // number of elements
+}
+
+// newLiteBuffer returns the description of data, a slice or an array, or a
+// pointer to one. When writable is true, arrays must be passed by pointer.
+// Elements must not hold Go pointers.
+func newLiteBuffer(data interface{}, writable bool) (liteBuffer, error) {
+	rv := reflect.ValueOf(data)
+	v := reflect.Indirect(rv)
+	var buf liteBuffer
+	switch v.Kind() {
+	case reflect.Slice:
+	case reflect.Array:
+		if !v.CanAddr() {
+			if writable {
+				return buf, fmt.Errorf("hdf5: read expects a pointer value")
+			}
+			a := reflect.New(v.Type()).Elem()
+			a.Set(v)
+			v = a
+		}
+	default:
+		return buf, fmt.Errorf("hdf5: expected a slice or an array, not %T", data)
+	}
+
+	buf.elem = v.Type().Elem()
+	for buf.elem.Kind() == reflect.Array {
+		buf.elem = buf.elem.Elem()
+	}
+	if hasGoPointers(buf.elem) {
+		return buf, fmt.Errorf("hdf5: can not transfer %T: elements hold Go pointers", data)
+	}
+	size := buf.elem.Size()
+	if size == 0 {
+		return buf, fmt.Errorf("hdf5: can not transfer %T: elements have zero size", data)
+	}
+	buf.n = int(uintptr(v.Len()) * v.Type().Elem().Size() / size)
+	if buf.n > 0 {
+		buf.addr = unsafe.Pointer(v.Index(0).UnsafeAddr())
+	}
+	return buf, nil
+}
+
+// liteDatatype returns the datatype of the elements of type t of a dataset
+// transferred by the H5LT functions, which must have the size of t.
+// NewDataTypeFromType maps int and uint to the C int types, so the integer
+// datatype of their size is used instead.
+func liteDatatype(t reflect.Type) (*Datatype, error) {
+	var (
+		dtype *Datatype
+		err   error
+	)
+	enum := reflect.PtrTo(t).Implements(_go_enum_t)
+	switch {
+	case t.Kind() == reflect.Int && !enum && t.Size() == 8:
+		dtype, err = T_NATIVE_INT64.Copy()
+	case t.Kind() == reflect.Uint && !enum && t.Size() == 8:
+		dtype, err = T_NATIVE_UINT64.Copy()
+	default:
+		dtype, err = NewDataTypeFromType(t)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if size := dtype.Size(); size != uint(t.Size()) {
+		dtype.Close()
+		return nil, fmt.Errorf("hdf5: datatype size mismatch for %v elements (got %d, want %d)", t, size, t.Size())
+	}
+	return dtype, nil
+}
+
+// liteKind returns the kind of the typed H5LT attribute functions for
+// elements of type t.
+func liteKind(t reflect.Type) (C.int, error) {
+	switch t.Kind() {
+	case reflect.Int8:
+		return C._GO_HDF5_LT_CHAR, nil
+	case reflect.Uint8:
+		return C._GO_HDF5_LT_UCHAR, nil
+	case reflect.Int16:
+		return C._GO_HDF5_LT_SHORT, nil
+	case reflect.Uint16:
+		return C._GO_HDF5_LT_USHORT, nil
+	case reflect.Int32:
+		return C._GO_HDF5_LT_INT, nil
+	case reflect.Uint32:
+		return C._GO_HDF5_LT_UINT, nil
+	case reflect.Int64:
+		return C._GO_HDF5_LT_LLONG, nil
+	case reflect.Uint64:
+		return C._GO_HDF5_LT_ULLONG, nil
+	case reflect.Float32:
+		return C._GO_HDF5_LT_FLOAT, nil
+	case reflect.Float64:
+		return C._GO_HDF5_LT_DOUBLE, nil
+	}
+	return 0, fmt.Errorf("hdf5: unsupported attribute element type %v", t)
+}
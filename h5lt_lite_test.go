@@ -0,0 +1,161 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hdf5
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestLite(t *testing.T) {
+	f, err := CreateFile(fname, F_ACC_TRUNC)
+	if err != nil {
+		t.Fatalf("CreateFile failed: %s", err)
+	}
+	defer os.Remove(fname)
+	defer f.Close()
+
+	type point struct {
+		X, Y float64
+		N    int32
+	}
+
+	data := []float64{1, 2, 3, 4, 5, 6}
+	if err := f.MakeDataset("data", []uint{2, 3}, data); err != nil {
+		t.Fatalf("MakeDataset failed: %s", err)
+	}
+	if err := f.MakeDataset("bad", []uint{2, 2}, data); err == nil {
+		t.Errorf("expected an error on mismatched dimensions")
+	}
+	points := [2]point{{1, 2, 3}, {4, 5, 6}}
+	if err := f.MakeDataset("points", []uint{2}, &points); err != nil {
+		t.Fatalf("MakeDataset failed: %s", err)
+	}
+
+	info, err := f.DatasetInfo("data")
+	if err != nil {
+		t.Fatalf("DatasetInfo failed: %s", err)
+	}
+	if want := (DataInfo{Dims: []uint{2, 3}, Class: T_FLOAT, Size: 8}); !reflect.DeepEqual(info, want) {
+		t.Errorf("invalid dataset info: got %+v, want %+v", info, want)
+	}
+
+	got := make([]float64, 6)
+	if err := f.ReadDataset("data", got); err != nil {
+		t.Fatalf("ReadDataset failed: %s", err)
+	}
+	if !reflect.DeepEqual(got, data) {
+		t.Errorf("invalid data: got %v, want %v", got, data)
+	}
+	if err := f.ReadDataset("data", make([]float64, 5)); err == nil {
+		t.Errorf("expected an error reading into a too small buffer")
+	}
+	var gotPoints [2]point
+	if err := f.ReadDataset("points", &gotPoints); err != nil {
+		t.Fatalf("ReadDataset failed: %s", err)
+	}
+	if gotPoints != points {
+		t.Errorf("invalid points: got %v, want %v", gotPoints, points)
+	}
+
+	ints := []int{-1 << 40, -1, 0, 1, 1 << 40, 7}
+	if err := f.MakeDataset("ints", []uint{3, 2}, ints); err != nil {
+		t.Fatalf("MakeDataset failed: %s", err)
+	}
+	gotInts := make([]int, len(ints))
+	if err := f.ReadDataset("ints", gotInts); err != nil {
+		t.Fatalf("ReadDataset failed: %s", err)
+	}
+	if !reflect.DeepEqual(gotInts, ints) {
+		t.Errorf("invalid ints: got %v, want %v", gotInts, ints)
+	}
+
+	// attributes.
+	if err := f.SetAttribute("data", "units", "m/s"); err != nil {
+		t.Fatalf("SetAttribute failed: %s", err)
+	}
+	if err := f.SetAttribute("data", "range", []int32{-1, 1}); err != nil {
+		t.Fatalf("SetAttribute failed: %s", err)
+	}
+	if err := f.SetAttribute("data", "scale", 0.5); err != nil {
+		t.Fatalf("SetAttribute failed: %s", err)
+	}
+	if err := f.SetAttribute("data", "count", 3); err == nil {
+		t.Errorf("expected an error writing an int attribute")
+	}
+
+	var units string
+	if err := f.ReadAttribute("data", "units", &units); err != nil {
+		t.Fatalf("ReadAttribute failed: %s", err)
+	}
+	if units != "m/s" {
+		t.Errorf("invalid string attribute: got %q, want %q", units, "m/s")
+	}
+	rng := make([]int32, 2)
+	if err := f.ReadAttribute("data", "range", rng); err != nil {
+		t.Fatalf("ReadAttribute failed: %s", err)
+	}
+	if !reflect.DeepEqual(rng, []int32{-1, 1}) {
+		t.Errorf("invalid slice attribute: got %v", rng)
+	}
+	var scale float64
+	if err := f.ReadAttribute("data", "scale", &scale); err != nil {
+		t.Fatalf("ReadAttribute failed: %s", err)
+	}
+	if scale != 0.5 {
+		t.Errorf("invalid scalar attribute: got %v, want 0.5", scale)
+	}
+	ids := []uint64{1 << 40, 1<<64 - 1}
+	for i := 0; i < 2; i++ {
+		// Writing again replaces the attribute.
+		if err := f.SetAttribute("data", "ids", ids); err != nil {
+			t.Fatalf("SetAttribute failed: %s", err)
+		}
+	}
+	gotIDs := make([]uint64, 2)
+	if err := f.ReadAttribute("data", "ids", gotIDs); err != nil {
+		t.Fatalf("ReadAttribute failed: %s", err)
+	}
+	if !reflect.DeepEqual(gotIDs, ids) {
+		t.Errorf("invalid uint64 attribute: got %v, want %v", gotIDs, ids)
+	}
+	info, err = f.AttributeDataInfo("data", "range")
+	if err != nil {
+		t.Fatalf("AttributeDataInfo failed: %s", err)
+	}
+	if want := (DataInfo{Dims: []uint{2}, Class: T_INTEGER, Size: 4}); !reflect.DeepEqual(info, want) {
+		t.Errorf("invalid attribute info: got %+v, want %+v", info, want)
+	}
+
+	// datatypes as DDL text.
+	text, err := T_STD_I32LE.DDL()
+	if err != nil {
+		t.Fatalf("DDL failed: %s", err)
+	}
+	if text != "H5T_STD_I32LE" {
+		t.Errorf("invalid DDL: got %q", text)
+	}
+	ctype, err := NewDatatypeFromValue(point{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ctype.Close()
+	text, err = ctype.DDL()
+	if err != nil {
+		t.Fatalf("DDL failed: %s", err)
+	}
+	dtype, err := ParseDatatype(text)
+	if err != nil {
+		t.Fatalf("ParseDatatype(%q) failed: %s", text, err)
+	}
+	defer dtype.Close()
+	if !dtype.Equal(ctype) {
+		t.Errorf("datatype does not round trip through DDL %q", text)
+	}
+	if _, err := ParseDatatype("H5T_NOT_A_TYPE"); err == nil {
+		t.Errorf("expected an error parsing an invalid datatype")
+	}
+}
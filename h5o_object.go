@@ -0,0 +1,158 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hdf5
+
+// #include "hdf5.h"
+// #include <stdint.h>
+//
+// extern herr_t _go_hdf5_ovisit_cb(hid_t id, char *name, H5O_info_t *info, void *data);
+//
+// static inline herr_t _go_hdf5_ovisit(hid_t id, H5_index_t idx, uintptr_t handle) {
+//   return H5Ovisit(id, idx, H5_ITER_INC, (H5O_iterate_t)(_go_hdf5_ovisit_cb), (void*)(handle));
+// }
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"runtime/cgo"
+	"strings"
+)
+
+// ObjectType describes the type of an object in a file.
+type ObjectType C.H5O_type_t
+
+const (
+	O_TYPE_UNKNOWN        ObjectType = C.H5O_TYPE_UNKNOWN        // Unknown object type
+	O_TYPE_GROUP          ObjectType = C.H5O_TYPE_GROUP          // Object is a group
+	O_TYPE_DATASET        ObjectType = C.H5O_TYPE_DATASET        // Object is a dataset
+	O_TYPE_NAMED_DATATYPE ObjectType = C.H5O_TYPE_NAMED_DATATYPE // Object is a named data type
+)
+
+func (typ ObjectType) String() string {
+	switch typ {
+	case O_TYPE_UNKNOWN:
+		return "unknown"
+	case O_TYPE_GROUP:
+		return "group"
+	case O_TYPE_DATASET:
+		return "dataset"
+	case O_TYPE_NAMED_DATATYPE:
+		return "named datatype"
+	default:
+		return fmt.Sprintf("ObjectType(%d)", int(typ))
+	}
+}
+
+// ObjectInfo describes an object in a file.
+type ObjectInfo struct {
+	Type     ObjectType // Type of the object
+	FileNo   uint64     // Number of the file the object is in
+	Addr     uint64     // Address of the object header in the file
+	RefCount uint       // Number of hard links to the object
+	NumAttrs uint       // Number of attributes attached to the object
+}
+
+func newObjectInfo(info *C.H5O_info_t) ObjectInfo {
+	return ObjectInfo{
+		Type:     ObjectType(info._type),
+		FileNo:   uint64(info.fileno),
+		Addr:     uint64(info.addr),
+		RefCount: uint(info.rc),
+		NumAttrs: uint(info.num_attrs),
+	}
+}
+
+// SkipGroup is used as a return value from a WalkFunc to indicate that
+// the group named in the call is to be skipped. It is not returned
+// as an error by any function.
+//
+// Walk relies on H5Ovisit, which visits every object once: the content of
+// a skipped group is still traversed by HDF5, only the calls to the
+// WalkFunc are skipped. Objects with hard links both inside a skipped group
+// and outside of it are not reported when HDF5 reaches them first through
+// the skipped group.
+var SkipGroup = errors.New("hdf5: skip this group")
+
+// WalkFunc is the type of the function called by Walk for each object.
+//
+// The path argument is the path of the object, relative to the location
+// Walk was called on. This location itself is reported as ".".
+//
+// If the function returns SkipGroup when invoked on a group, Walk skips
+// the group's content. Any other non-nil error stops the walk and is
+// returned by Walk.
+type WalkFunc func(path string, info ObjectInfo) error
+
+// Walk recursively visits all the objects reachable from this location,
+// in increasing name order, calling fn for each of them.
+// Objects reachable through several hard links are only visited once.
+func (g *CommonFG) Walk(fn WalkFunc) error {
+	return g.WalkIndex(INDEX_NAME, fn)
+}
+
+// WalkIndex is like Walk but visits the objects in the increasing order of
+// the given index. Iterating with INDEX_CRT_ORDER requires groups to be
+// created with link creation order tracking enabled.
+func (g *CommonFG) WalkIndex(idx IndexType, fn WalkFunc) error {
+	h5lock()
+	defer h5unlock()
+
+	w := &walker{
+		fn:   fn,
+		seen: make(map[objectAddr]bool),
+	}
+	h := cgo.NewHandle(w)
+	defer h.Delete()
+
+	err := h5err(C._go_hdf5_ovisit(g.id, C.H5_index_t(idx), C.uintptr_t(h)))
+	if w.err != nil {
+		return w.err
+	}
+	return err
+}
+
+// objectAddr uniquely identifies an object across files.
+type objectAddr struct {
+	fileno uint64
+	addr   uint64
+}
+
+// walker holds the state of a Walk.
+type walker struct {
+	fn    WalkFunc
+	seen  map[objectAddr]bool
+	skips []string
+	err   error
+}
+
+func (w *walker) visit(path string, info ObjectInfo) C.herr_t {
+	for _, prefix := range w.skips {
+		if strings.HasPrefix(path, prefix) {
+			return C.H5_ITER_CONT
+		}
+	}
+
+	key := objectAddr{fileno: info.FileNo, addr: info.Addr}
+	if w.seen[key] {
+		return C.H5_ITER_CONT
+	}
+	w.seen[key] = true
+
+	switch err := w.fn(path, info); {
+	case err == nil:
+		return C.H5_ITER_CONT
+	case err == SkipGroup && path == ".":
+		return C.H5_ITER_STOP
+	case err == SkipGroup:
+		if info.Type == O_TYPE_GROUP {
+			w.skips = append(w.skips, path+"/")
+		}
+		return C.H5_ITER_CONT
+	default:
+		w.err = err
+		return C.H5_ITER_STOP
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hdf5
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestWalk(t *testing.T) {
+	f, err := CreateFile(fname, F_ACC_TRUNC)
+	if err != nil {
+		t.Fatalf("CreateFile failed: %s", err)
+	}
+	defer os.Remove(fname)
+	defer f.Close()
+
+	scalar, err := CreateDataspace(S_SCALAR)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer scalar.Close()
+
+	for _, name := range []string{"a", "b"} {
+		g, err := f.CreateGroup(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dset, err := g.CreateDataset(name+"-dset", T_NATIVE_INT32, scalar)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dset.Close()
+		g.Close()
+	}
+	// create a cycle.
+	if err := f.CreateHardLink("/a", "/a/self"); err != nil {
+		t.Fatal(err)
+	}
+
+	type visit struct {
+		path string
+		typ  ObjectType
+	}
+
+	for _, tc := range []struct {
+		name string
+		fn   func(path string, info ObjectInfo) error
+		want []visit
+		err  error
+	}{
+		{
+			name: "all",
+			want: []visit{
+				{".", O_TYPE_GROUP},
+				{"a", O_TYPE_GROUP},
+				{"a/a-dset", O_TYPE_DATASET},
+				{"b", O_TYPE_GROUP},
+				{"b/b-dset", O_TYPE_DATASET},
+			},
+		},
+		{
+			name: "skip",
+			fn: func(path string, info ObjectInfo) error {
+				if path == "a" {
+					return SkipGroup
+				}
+				return nil
+			},
+			want: []visit{
+				{".", O_TYPE_GROUP},
+				{"a", O_TYPE_GROUP},
+				{"b", O_TYPE_GROUP},
+				{"b/b-dset", O_TYPE_DATASET},
+			},
+		},
+		{
+			name: "skip-root",
+			fn: func(path string, info ObjectInfo) error {
+				return SkipGroup
+			},
+			want: []visit{
+				{".", O_TYPE_GROUP},
+			},
+		},
+		{
+			name: "error",
+			fn: func(path string, info ObjectInfo) error {
+				if info.Type == O_TYPE_DATASET {
+					return errStopWalk
+				}
+				return nil
+			},
+			want: []visit{
+				{".", O_TYPE_GROUP},
+				{"a", O_TYPE_GROUP},
+				{"a/a-dset", O_TYPE_DATASET},
+			},
+			err: errStopWalk,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []visit
+			err := f.Walk(func(path string, info ObjectInfo) error {
+				got = append(got, visit{path, info.Type})
+				if tc.fn != nil {
+					return tc.fn(path, info)
+				}
+				return nil
+			})
+			if err != tc.err {
+				t.Fatalf("invalid error: got=%v, want=%v", err, tc.err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid walk:\ngot= %v\nwant=%v", got, tc.want)
+			}
+		})
+	}
+}
+
+var errStopWalk = errors.New("stop walk")
+
+func TestWalkCreationOrder(t *testing.T) {
+	f, err := CreateFile(fname, F_ACC_TRUNC)
+	if err != nil {
+		t.Fatalf("CreateFile failed: %s", err)
+	}
+	defer os.Remove(fname)
+	defer f.Close()
+
+	gcpl, err := NewPropList(P_GROUP_CREATE)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer gcpl.Close()
+	if err := gcpl.SetLinkCreationOrder(P_CRT_ORDER_TRACKED | P_CRT_ORDER_INDEXED); err != nil {
+		t.Fatal(err)
+	}
+
+	g, err := f.CreateGroupWith("ordered", gcpl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer g.Close()
+
+	scalar, err := CreateDataspace(S_SCALAR)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer scalar.Close()
+
+	names := []string{"z", "x", "y"}
+	for _, name := range names {
+		dset, err := g.CreateDataset(name, T_NATIVE_INT32, scalar)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dset.Close()
+	}
+
+	for _, tc := range []struct {
+		idx  IndexType
+		want []string
+	}{
+		{INDEX_NAME, []string{".", "x", "y", "z"}},
+		{INDEX_CRT_ORDER, []string{".", "z", "x", "y"}},
+	} {
+		var got []string
+		err := g.WalkIndex(tc.idx, func(path string, info ObjectInfo) error {
+			got = append(got, path)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("could not walk group: %v", err)
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Fatalf("invalid walk order for index %d:\ngot= %v\nwant=%v", tc.idx, got, tc.want)
+		}
+	}
+}
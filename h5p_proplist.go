@@ -10,11 +10,83 @@ package hdf5
 // static inline hid_t _go_hdf5_H5P_DEFAULT() { return H5P_DEFAULT; }
 // static inline hid_t _go_hdf5_H5P_DATASET_CREATE() { return H5P_DATASET_CREATE; }
 // static inline hid_t _go_hdf5_H5P_DATASET_ACCESS() { return H5P_DATASET_ACCESS; }
+// static inline hid_t _go_hdf5_H5P_GROUP_CREATE() { return H5P_GROUP_CREATE; }
+// static inline hid_t _go_hdf5_H5P_FILE_CREATE() { return H5P_FILE_CREATE; }
+// static inline hid_t _go_hdf5_H5P_FILE_ACCESS() { return H5P_FILE_ACCESS; }
+//
+// static herr_t _go_hdf5_set_mdc_size(hid_t fapl, size_t initial, size_t min, size_t max) {
+//   H5AC_cache_config_t config;
+//   config.version = H5AC__CURR_CACHE_CONFIG_VERSION;
+//   if (H5Pget_mdc_config(fapl, &config) < 0) {
+//     return -1;
+//   }
+//   config.set_initial_size = 1;
+//   config.initial_size = initial;
+//   config.min_size = min;
+//   config.max_size = max;
+//   return H5Pset_mdc_config(fapl, &config);
+// }
+//
+// static herr_t _go_hdf5_get_mdc_size(hid_t fapl, size_t *initial, size_t *min, size_t *max) {
+//   H5AC_cache_config_t config;
+//   config.version = H5AC__CURR_CACHE_CONFIG_VERSION;
+//   if (H5Pget_mdc_config(fapl, &config) < 0) {
+//     return -1;
+//   }
+//   *initial = config.initial_size;
+//   *min = config.min_size;
+//   *max = config.max_size;
+//   return 0;
+// }
+//
+// static herr_t _go_hdf5_set_fapl_core(hid_t fapl, size_t increment, int backing_store) {
+//   return H5Pset_fapl_core(fapl, increment, backing_store != 0);
+// }
+//
+// static herr_t _go_hdf5_get_fapl_core(hid_t fapl, size_t *increment, int *backing_store) {
+//   hbool_t b;
+//   if (H5Pget_fapl_core(fapl, increment, &b) < 0) {
+//     return -1;
+//   }
+//   *backing_store = b;
+//   return 0;
+// }
+//
+// #if H5_VERSION_GE(1,12,1) || (H5_VERS_MAJOR==1 && H5_VERS_MINOR==10 && H5_VERS_RELEASE>=7)
+// #define GO_HDF5_HAS_FILE_LOCKING 1
+// #else
+// #define GO_HDF5_HAS_FILE_LOCKING 0
+// #endif
+//
+// static herr_t _go_hdf5_set_file_locking(hid_t fapl, int use, int ignore) {
+// #if GO_HDF5_HAS_FILE_LOCKING
+//   return H5Pset_file_locking(fapl, use != 0, ignore != 0);
+// #else
+//   return -1;
+// #endif
+// }
+//
+// static herr_t _go_hdf5_get_file_locking(hid_t fapl, int *use, int *ignore) {
+// #if GO_HDF5_HAS_FILE_LOCKING
+//   hbool_t u, i;
+//   if (H5Pget_file_locking(fapl, &u, &i) < 0) {
+//     return -1;
+//   }
+//   *use = u;
+//   *ignore = i;
+//   return 0;
+// #else
+//   return -1;
+// #endif
+// }
 import "C"
 
 import (
 	"compress/zlib"
+	"errors"
 	"fmt"
+	"reflect"
+	"unsafe"
 )
 
 const (
@@ -34,6 +106,24 @@ var (
 	P_DEFAULT        *PropList = newPropList(C._go_hdf5_H5P_DEFAULT())
 	P_DATASET_CREATE PropType  = PropType(C._go_hdf5_H5P_DATASET_CREATE()) // Properties for dataset creation
 	P_DATASET_ACCESS PropType  = PropType(C._go_hdf5_H5P_DATASET_ACCESS()) // Properties for dataset access
+	P_GROUP_CREATE   PropType  = PropType(C._go_hdf5_H5P_GROUP_CREATE())   // Properties for group creation
+	P_FILE_CREATE    PropType  = PropType(C._go_hdf5_H5P_FILE_CREATE())    // Properties for file creation
+	P_FILE_ACCESS    PropType  = PropType(C._go_hdf5_H5P_FILE_ACCESS())    // Properties for file access
+)
+
+// LibVer is a version of the HDF5 library, used to bound the versions
+// of the objects created in a file.
+type LibVer C.H5F_libver_t
+
+const (
+	F_LIBVER_EARLIEST LibVer = C.H5F_LIBVER_EARLIEST // Earliest version possible for each object
+	F_LIBVER_LATEST   LibVer = C.H5F_LIBVER_LATEST   // Latest version of the library in use
+)
+
+// Creation order flags, used with SetLinkCreationOrder.
+const (
+	P_CRT_ORDER_TRACKED int = C.H5P_CRT_ORDER_TRACKED // Creation order is tracked
+	P_CRT_ORDER_INDEXED int = C.H5P_CRT_ORDER_INDEXED // Creation order is indexed, requires P_CRT_ORDER_TRACKED
 )
 
 func newPropList(id C.hid_t) *PropList {
@@ -43,6 +133,9 @@ func newPropList(id C.hid_t) *PropList {
 // NewPropList creates a new PropList as an instance of a property list class.
 // The returned proplist must be closed by the user when it is no longer needed.
 func NewPropList(cls_id PropType) (*PropList, error) {
+	h5lock()
+	defer h5unlock()
+
 	hid := C.H5Pcreate(C.hid_t(cls_id))
 	if err := checkID(hid); err != nil {
 		return nil, err
@@ -52,12 +145,18 @@ func NewPropList(cls_id PropType) (*PropList, error) {
 
 // Close terminates access to a PropList.
 func (p *PropList) Close() error {
+	h5lock()
+	defer h5unlock()
+
 	return p.closeWith(h5pclose)
 }
 
 // SetChunk sets the size of the chunks used to store a chunked layout dataset.
 // https://support.hdfgroup.org/HDF5/doc/RM/RM_H5P.html#Property-SetChunk
 func (p *PropList) SetChunk(dims []uint) error {
+	h5lock()
+	defer h5unlock()
+
 	ndims := len(dims)
 	if ndims <= 0 {
 		return fmt.Errorf("number of dimensions must be same size as the rank of the dataset, but zero received")
@@ -72,6 +171,9 @@ func (p *PropList) SetChunk(dims []uint) error {
 // GetChunk retrieves the size of chunks for the raw data of a chunked layout dataset.
 // https://support.hdfgroup.org/HDF5/doc/RM/RM_H5P.html#Property-GetChunk
 func (p *PropList) GetChunk(ndims int) (dims []uint, err error) {
+	h5lock()
+	defer h5unlock()
+
 	if ndims <= 0 {
 		err = fmt.Errorf("number of dimensions must be same size as the rank of the dataset, but nonpositive value received")
 		return
@@ -91,6 +193,9 @@ func (p *PropList) GetChunk(ndims int) (dims []uint, err error) {
 // If level is set as DefaultCompression, 6 will be used.
 // https://support.hdfgroup.org/HDF5/doc/RM/RM_H5P.html#Property-SetDeflate
 func (p *PropList) SetDeflate(level int) error {
+	h5lock()
+	defer h5unlock()
+
 	if level == DefaultCompression {
 		level = 6
 	}
@@ -101,12 +206,18 @@ func (p *PropList) SetDeflate(level int) error {
 // To reset them as default, use `D_CHUNK_CACHE_NSLOTS_DEFAULT`, `D_CHUNK_CACHE_NBYTES_DEFAULT` and `D_CHUNK_CACHE_W0_DEFAULT`.
 // https://support.hdfgroup.org/HDF5/doc/RM/RM_H5P.html#Property-SetChunkCache
 func (p *PropList) SetChunkCache(nslots, nbytes int, w0 float64) error {
+	h5lock()
+	defer h5unlock()
+
 	return h5err(C.H5Pset_chunk_cache(C.hid_t(p.id), C.size_t(nslots), C.size_t(nbytes), C.double(w0)))
 }
 
 // GetChunkCache retrieves the number of chunk slots in the raw data chunk cache hash table.
 // https://support.hdfgroup.org/HDF5/doc/RM/RM_H5P.html#Property-GetChunkCache
 func (p *PropList) GetChunkCache() (nslots, nbytes int, w0 float64, err error) {
+	h5lock()
+	defer h5unlock()
+
 	var (
 		c_nslots C.size_t
 		c_nbytes C.size_t
@@ -116,12 +227,302 @@ func (p *PropList) GetChunkCache() (nslots, nbytes int, w0 float64, err error) {
 	return int(c_nslots), int(c_nbytes), float64(c_w0), err
 }
 
+// SetLinkCreationOrder sets whether the creation order of links in a group
+// is tracked and indexed. flags is a combination of P_CRT_ORDER_TRACKED
+// and P_CRT_ORDER_INDEXED.
+// https://support.hdfgroup.org/HDF5/doc/RM/RM_H5P.html#Property-SetLinkCreationOrder
+func (p *PropList) SetLinkCreationOrder(flags int) error {
+	h5lock()
+	defer h5unlock()
+
+	return h5err(C.H5Pset_link_creation_order(C.hid_t(p.id), C.uint(flags)))
+}
+
+// SetLayout sets the storage layout of the raw data of a dataset.
+// Setting a chunked layout requires a call to SetChunk, which also sets it.
+// https://support.hdfgroup.org/HDF5/doc/RM/RM_H5P.html#Property-SetLayout
+func (p *PropList) SetLayout(layout Layout) error {
+	h5lock()
+	defer h5unlock()
+
+	return h5err(C.H5Pset_layout(C.hid_t(p.id), C.H5D_layout_t(layout)))
+}
+
+// Layout returns the storage layout of the raw data of a dataset.
+// https://support.hdfgroup.org/HDF5/doc/RM/RM_H5P.html#Property-GetLayout
+func (p *PropList) Layout() (Layout, error) {
+	h5lock()
+	defer h5unlock()
+
+	layout := C.H5Pget_layout(C.hid_t(p.id))
+	if err := h5err(C.herr_t(layout)); err != nil {
+		return 0, err
+	}
+	return Layout(layout), nil
+}
+
+// SetFillValue sets the fill value of a dataset. value must be a pointer
+// to a value of a Go type matching the datatype dtype.
+// https://support.hdfgroup.org/HDF5/doc/RM/RM_H5P.html#Property-SetFillValue
+func (p *PropList) SetFillValue(dtype *Datatype, value interface{}) error {
+	h5lock()
+	defer h5unlock()
+
+	addr, err := fillValueAddr(value)
+	if err != nil {
+		return err
+	}
+	return h5err(C.H5Pset_fill_value(C.hid_t(p.id), dtype.id, addr))
+}
+
+// FillValue reads the fill value of a dataset, converted to the datatype
+// dtype, into value, which must be a pointer to a value of a Go type
+// matching dtype.
+// https://support.hdfgroup.org/HDF5/doc/RM/RM_H5P.html#Property-GetFillValue
+func (p *PropList) FillValue(dtype *Datatype, value interface{}) error {
+	h5lock()
+	defer h5unlock()
+
+	addr, err := fillValueAddr(value)
+	if err != nil {
+		return err
+	}
+	return h5err(C.H5Pget_fill_value(C.hid_t(p.id), dtype.id, addr))
+}
+
+func fillValueAddr(value interface{}) (unsafe.Pointer, error) {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return nil, fmt.Errorf("hdf5: fill value must be a non-nil pointer, got %T", value)
+	}
+	return unsafe.Pointer(v.Pointer()), nil
+}
+
+// SetFillTime sets the time at which fill values are written to a dataset.
+// https://support.hdfgroup.org/HDF5/doc/RM/RM_H5P.html#Property-SetFillTime
+func (p *PropList) SetFillTime(t FillTime) error {
+	h5lock()
+	defer h5unlock()
+
+	return h5err(C.H5Pset_fill_time(C.hid_t(p.id), C.H5D_fill_time_t(t)))
+}
+
+// FillTime returns the time at which fill values are written to a dataset.
+// https://support.hdfgroup.org/HDF5/doc/RM/RM_H5P.html#Property-GetFillTime
+func (p *PropList) FillTime() (FillTime, error) {
+	h5lock()
+	defer h5unlock()
+
+	var t C.H5D_fill_time_t
+	err := h5err(C.H5Pget_fill_time(C.hid_t(p.id), &t))
+	return FillTime(t), err
+}
+
+// SetAllocTime sets the time at which storage space is allocated for a dataset.
+// https://support.hdfgroup.org/HDF5/doc/RM/RM_H5P.html#Property-SetAllocTime
+func (p *PropList) SetAllocTime(t AllocTime) error {
+	h5lock()
+	defer h5unlock()
+
+	return h5err(C.H5Pset_alloc_time(C.hid_t(p.id), C.H5D_alloc_time_t(t)))
+}
+
+// AllocTime returns the time at which storage space is allocated for a dataset.
+// https://support.hdfgroup.org/HDF5/doc/RM/RM_H5P.html#Property-GetAllocTime
+func (p *PropList) AllocTime() (AllocTime, error) {
+	h5lock()
+	defer h5unlock()
+
+	var t C.H5D_alloc_time_t
+	err := h5err(C.H5Pget_alloc_time(C.hid_t(p.id), &t))
+	return AllocTime(t), err
+}
+
+// SetLibverBounds sets the bounds on the library versions used to create
+// objects in a file accessed with this file access property list.
+// https://support.hdfgroup.org/HDF5/doc/RM/RM_H5P.html#Property-SetLibverBounds
+func (p *PropList) SetLibverBounds(low, high LibVer) error {
+	h5lock()
+	defer h5unlock()
+
+	return h5err(C.H5Pset_libver_bounds(C.hid_t(p.id), C.H5F_libver_t(low), C.H5F_libver_t(high)))
+}
+
+// LibverBounds retrieves the bounds on the library versions used to create objects.
+// https://support.hdfgroup.org/HDF5/doc/RM/RM_H5P.html#Property-GetLibverBounds
+func (p *PropList) LibverBounds() (low, high LibVer, err error) {
+	h5lock()
+	defer h5unlock()
+
+	var c_low, c_high C.H5F_libver_t
+	err = h5err(C.H5Pget_libver_bounds(C.hid_t(p.id), &c_low, &c_high))
+	return LibVer(c_low), LibVer(c_high), err
+}
+
+// SetUserblock sets the size of the user block reserved at the beginning
+// of a file created with this file creation property list.
+// size must be 0 or a power of two greater or equal to 512.
+// https://support.hdfgroup.org/HDF5/doc/RM/RM_H5P.html#Property-SetUserblock
+func (p *PropList) SetUserblock(size uint) error {
+	h5lock()
+	defer h5unlock()
+
+	return h5err(C.H5Pset_userblock(C.hid_t(p.id), C.hsize_t(size)))
+}
+
+// Userblock retrieves the size of the user block.
+// https://support.hdfgroup.org/HDF5/doc/RM/RM_H5P.html#Property-GetUserblock
+func (p *PropList) Userblock() (uint, error) {
+	h5lock()
+	defer h5unlock()
+
+	var size C.hsize_t
+	err := h5err(C.H5Pget_userblock(C.hid_t(p.id), &size))
+	return uint(size), err
+}
+
+// SetSieveBufSize sets the maximum size, in bytes, of the data sieve buffer.
+// https://support.hdfgroup.org/HDF5/doc/RM/RM_H5P.html#Property-SetSieveBufSize
+func (p *PropList) SetSieveBufSize(size uint) error {
+	h5lock()
+	defer h5unlock()
+
+	return h5err(C.H5Pset_sieve_buf_size(C.hid_t(p.id), C.size_t(size)))
+}
+
+// SieveBufSize retrieves the maximum size of the data sieve buffer.
+// https://support.hdfgroup.org/HDF5/doc/RM/RM_H5P.html#Property-GetSieveBufSize
+func (p *PropList) SieveBufSize() (uint, error) {
+	h5lock()
+	defer h5unlock()
+
+	var size C.size_t
+	err := h5err(C.H5Pget_sieve_buf_size(C.hid_t(p.id), &size))
+	return uint(size), err
+}
+
+// SetMetadataCacheSize sets the initial, minimum and maximum sizes, in bytes,
+// of the metadata cache of a file accessed with this file access property list.
+// The other parameters of the metadata cache configuration are left unchanged.
+// https://support.hdfgroup.org/HDF5/doc/RM/RM_H5P.html#Property-SetMdcConfig
+func (p *PropList) SetMetadataCacheSize(initial, min, max uint) error {
+	h5lock()
+	defer h5unlock()
+
+	return h5err(C._go_hdf5_set_mdc_size(C.hid_t(p.id), C.size_t(initial), C.size_t(min), C.size_t(max)))
+}
+
+// MetadataCacheSize retrieves the initial, minimum and maximum sizes of the metadata cache.
+// https://support.hdfgroup.org/HDF5/doc/RM/RM_H5P.html#Property-GetMdcConfig
+func (p *PropList) MetadataCacheSize() (initial, min, max uint, err error) {
+	h5lock()
+	defer h5unlock()
+
+	var c_initial, c_min, c_max C.size_t
+	err = h5err(C._go_hdf5_get_mdc_size(C.hid_t(p.id), &c_initial, &c_min, &c_max))
+	return uint(c_initial), uint(c_min), uint(c_max), err
+}
+
+// SetAlignment sets the alignment properties of a file access property list:
+// any file object greater than or equal in size to threshold bytes will be
+// aligned on an address which is a multiple of alignment.
+// https://support.hdfgroup.org/HDF5/doc/RM/RM_H5P.html#Property-SetAlignment
+func (p *PropList) SetAlignment(threshold, alignment uint) error {
+	h5lock()
+	defer h5unlock()
+
+	return h5err(C.H5Pset_alignment(C.hid_t(p.id), C.hsize_t(threshold), C.hsize_t(alignment)))
+}
+
+// Alignment retrieves the current settings for alignment properties.
+// https://support.hdfgroup.org/HDF5/doc/RM/RM_H5P.html#Property-GetAlignment
+func (p *PropList) Alignment() (threshold, alignment uint, err error) {
+	h5lock()
+	defer h5unlock()
+
+	var c_threshold, c_alignment C.hsize_t
+	err = h5err(C.H5Pget_alignment(C.hid_t(p.id), &c_threshold, &c_alignment))
+	return uint(c_threshold), uint(c_alignment), err
+}
+
+// SetFileLocking sets whether file locking is used when opening a file
+// with this file access property list, and whether failures to lock are
+// ignored when file locking is disabled on the file system.
+// It requires HDF5 1.10.7 or 1.12.1 and later.
+// https://support.hdfgroup.org/HDF5/doc/RM/RM_H5P.html#Property-SetFileLocking
+func (p *PropList) SetFileLocking(use, ignoreWhenDisabled bool) error {
+	h5lock()
+	defer h5unlock()
+
+	if C.GO_HDF5_HAS_FILE_LOCKING == 0 {
+		return errNoFileLocking
+	}
+	var c_use, c_ignore C.int
+	if use {
+		c_use = 1
+	}
+	if ignoreWhenDisabled {
+		c_ignore = 1
+	}
+	return h5err(C._go_hdf5_set_file_locking(C.hid_t(p.id), c_use, c_ignore))
+}
+
+// FileLocking retrieves the file locking properties.
+// It requires HDF5 1.10.7 or 1.12.1 and later.
+// https://support.hdfgroup.org/HDF5/doc/RM/RM_H5P.html#Property-GetFileLocking
+func (p *PropList) FileLocking() (use, ignoreWhenDisabled bool, err error) {
+	h5lock()
+	defer h5unlock()
+
+	if C.GO_HDF5_HAS_FILE_LOCKING == 0 {
+		return false, false, errNoFileLocking
+	}
+	var c_use, c_ignore C.int
+	err = h5err(C._go_hdf5_get_file_locking(C.hid_t(p.id), &c_use, &c_ignore))
+	return c_use != 0, c_ignore != 0, err
+}
+
+// SetFaplCore sets the file access property list to use the core driver,
+// which keeps the whole file in memory. Memory grows by increment bytes
+// at a time. If backingStore is true, the file contents are written to disk
+// when the file is closed.
+// https://support.hdfgroup.org/HDF5/doc/RM/RM_H5P.html#Property-SetFaplCore
+func (p *PropList) SetFaplCore(increment uint, backingStore bool) error {
+	h5lock()
+	defer h5unlock()
+
+	var c_backing C.int
+	if backingStore {
+		c_backing = 1
+	}
+	return h5err(C._go_hdf5_set_fapl_core(C.hid_t(p.id), C.size_t(increment), c_backing))
+}
+
+// FaplCore retrieves the properties of the core driver.
+// https://support.hdfgroup.org/HDF5/doc/RM/RM_H5P.html#Property-GetFaplCore
+func (p *PropList) FaplCore() (increment uint, backingStore bool, err error) {
+	h5lock()
+	defer h5unlock()
+
+	var (
+		c_increment C.size_t
+		c_backing   C.int
+	)
+	err = h5err(C._go_hdf5_get_fapl_core(C.hid_t(p.id), &c_increment, &c_backing))
+	return uint(c_increment), c_backing != 0, err
+}
+
+var errNoFileLocking = errors.New("hdf5: file locking properties require HDF5 1.10.7, or 1.12.1 and later")
+
 func h5pclose(id C.hid_t) C.herr_t {
 	return C.H5Pclose(id)
 }
 
 // Copy copies an existing PropList to create a new PropList.
 func (p *PropList) Copy() (*PropList, error) {
+	h5lock()
+	defer h5unlock()
+
 	hid := C.H5Pcopy(p.id)
 	if err := checkID(hid); err != nil {
 		return nil, err
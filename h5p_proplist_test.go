@@ -173,6 +173,116 @@ func TestChunkCache(t *testing.T) {
 	}
 }
 
+func TestLayoutAndFill(t *testing.T) {
+	f, err := CreateFile(fname, F_ACC_TRUNC)
+	if err != nil {
+		t.Fatalf("CreateFile failed: %s", err)
+	}
+	defer os.Remove(fname)
+	defer f.Close()
+
+	dspace, err := CreateSimpleDataspace([]uint{8}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dspace.Close()
+
+	for _, tc := range []struct {
+		layout Layout
+		alloc  AllocTime
+	}{
+		{D_COMPACT, D_ALLOC_TIME_EARLY},
+		{D_CONTIGUOUS, D_ALLOC_TIME_EARLY},
+		{D_CONTIGUOUS, D_ALLOC_TIME_LATE},
+	} {
+		dcpl, err := NewPropList(P_DATASET_CREATE)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer dcpl.Close()
+
+		if err := dcpl.SetLayout(tc.layout); err != nil {
+			t.Fatalf("SetLayout failed: %s", err)
+		}
+		if layout, err := dcpl.Layout(); err != nil || layout != tc.layout {
+			t.Fatalf("Layout: got (%d, %v), want (%d, nil)", layout, err, tc.layout)
+		}
+		if err := dcpl.SetAllocTime(tc.alloc); err != nil {
+			t.Fatalf("SetAllocTime failed: %s", err)
+		}
+		if alloc, err := dcpl.AllocTime(); err != nil || alloc != tc.alloc {
+			t.Fatalf("AllocTime: got (%d, %v), want (%d, nil)", alloc, err, tc.alloc)
+		}
+		if err := dcpl.SetFillTime(D_FILL_TIME_ALLOC); err != nil {
+			t.Fatalf("SetFillTime failed: %s", err)
+		}
+		if ft, err := dcpl.FillTime(); err != nil || ft != D_FILL_TIME_ALLOC {
+			t.Fatalf("FillTime: got (%d, %v), want (%d, nil)", ft, err, D_FILL_TIME_ALLOC)
+		}
+		fill := int32(-1)
+		if err := dcpl.SetFillValue(T_NATIVE_INT32, &fill); err != nil {
+			t.Fatalf("SetFillValue failed: %s", err)
+		}
+		var got int32
+		if err := dcpl.FillValue(T_NATIVE_INT32, &got); err != nil || got != fill {
+			t.Fatalf("FillValue: got (%d, %v), want (%d, nil)", got, err, fill)
+		}
+		if err := dcpl.SetFillValue(T_NATIVE_INT32, fill); err == nil {
+			t.Fatalf("expected an error setting a fill value from a non-pointer")
+		}
+
+		name := fmt.Sprintf("dset-%d-%d", tc.layout, tc.alloc)
+		dset, err := f.CreateDatasetWith(name, T_NATIVE_INT32, dspace, dcpl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer dset.Close()
+
+		_, err = dset.Offset()
+		switch {
+		case tc.layout == D_CONTIGUOUS && tc.alloc == D_ALLOC_TIME_EARLY:
+			if err != nil {
+				t.Fatalf("Offset failed on %s: %s", name, err)
+			}
+		default:
+			if err == nil {
+				t.Fatalf("expected an error for the offset of %s", name)
+			}
+		}
+		if tc.alloc == D_ALLOC_TIME_LATE && dset.StorageSize() != 0 {
+			t.Fatalf("unexpected storage allocated for %s", name)
+		}
+
+		filespace := dset.Space()
+		if err := filespace.SelectHyperslab([]uint{2}, nil, []uint{2}, nil); err != nil {
+			t.Fatal(err)
+		}
+		memspace, err := CreateSimpleDataspace([]uint{2}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := dset.WriteSubset(&[]int32{7, 8}, memspace, filespace); err != nil {
+			t.Fatal(err)
+		}
+		memspace.Close()
+		filespace.Close()
+
+		if size := dset.StorageSize(); size != 8*4 {
+			t.Fatalf("StorageSize of %s: got %d, want %d", name, size, 8*4)
+		}
+		data := make([]int32, 8)
+		if err := dset.Read(&data); err != nil {
+			t.Fatal(err)
+		}
+		want := []int32{-1, -1, 7, 8, -1, -1, -1, -1}
+		for i := range want {
+			if data[i] != want[i] {
+				t.Fatalf("invalid data in %s: got=%v, want=%v", name, data, want)
+			}
+		}
+	}
+}
+
 func save(fn, dsn string, dims []uint, dcpl *PropList) ([]float64, error) {
 	f, err := CreateFile(fn, F_ACC_TRUNC)
 	if err != nil {
@@ -263,3 +373,38 @@ func checkChunkCache(nslots, nbytes int, w0 float64, dapl *PropList) error {
 	}
 	return nil
 }
+
+func TestFileLocking(t *testing.T) {
+	fapl, err := NewPropList(P_FILE_ACCESS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fapl.Close()
+
+	err = fapl.SetFileLocking(false, true)
+	if err == errNoFileLocking {
+		if _, _, err := fapl.FileLocking(); err != errNoFileLocking {
+			t.Fatalf("FileLocking: got %v, want %v", err, errNoFileLocking)
+		}
+		t.Skip(err)
+	}
+	if err != nil {
+		t.Fatalf("SetFileLocking failed: %s", err)
+	}
+	use, ignore, err := fapl.FileLocking()
+	if err != nil {
+		t.Fatalf("FileLocking failed: %s", err)
+	}
+	if use || !ignore {
+		t.Fatalf("FileLocking: got (%v, %v), want (false, true)", use, ignore)
+	}
+
+	f, err := CreateFileWith(fname, F_ACC_TRUNC, P_DEFAULT, fapl)
+	if err != nil {
+		t.Fatalf("CreateFileWith failed: %s", err)
+	}
+	defer os.Remove(fname)
+	if err := f.Close(); err != nil {
+		t.Fatalf("Close failed: %s", err)
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hdf5
+
+// #include "hdf5.h"
+// #include "hdf5_hl.h"
+//
+// static inline hsize_t _go_hdf5_pt_chunk_size(hid_t table) {
+// #if H5_VERSION_GE(1,10,0)
+//   hid_t dset, plist;
+//   hsize_t dim = 0;
+//   dset = H5PTget_dataset(table);
+//   if (dset < 0) {
+//     return 0;
+//   }
+//   plist = H5Dget_create_plist(dset);
+//   if (plist < 0) {
+//     return 0;
+//   }
+//   if (H5Pget_chunk(plist, 1, &dim) < 0) {
+//     dim = 0;
+//   }
+//   H5Pclose(plist);
+//   return dim;
+// #else
+//   return 0;
+// #endif
+// }
+import "C"
+
+// defaultPacketBatch is the number of packets read at a time by iterators
+// when the chunk size of a packet table is not known.
+const defaultPacketBatch = 1024
+
+// PacketIter is a cursor over the packets of a packet table, reading them
+// batch by batch from the current index of the table.
+type PacketIter[T any] struct {
+	t     *Table
+	batch int
+	buf   []T
+	pos   int
+	err   error
+}
+
+// Iter returns an iterator over the packets of t of type T, from the current
+// index of t to its last packet, reading batch packets at a time. A batch
+// of zero or less reads packets by chunk of the table. Packets are read as
+// Table.Next does, and advance the index of t.
+//
+// Iter is a function rather than a method of Table since methods can not
+// have type parameters.
+func Iter[T any](t *Table, batch int) *PacketIter[T] {
+	h5lock()
+	defer h5unlock()
+
+	if batch <= 0 {
+		batch = t.chunkSize()
+	}
+	return &PacketIter[T]{t: t, batch: batch, pos: -1}
+}
+
+// Next advances the iterator to the next packet, reading a new batch of
+// packets when the current one is exhausted. It returns false at the end
+// of the table, or on error.
+func (it *PacketIter[T]) Next() bool {
+	h5lock()
+	defer h5unlock()
+
+	if it.err != nil {
+		return false
+	}
+	it.pos++
+	if it.pos < len(it.buf) {
+		return true
+	}
+
+	n, err := it.t.remaining()
+	if err != nil {
+		it.err = err
+		return false
+	}
+	if n == 0 {
+		it.buf = it.buf[:0]
+		return false
+	}
+	if n > it.batch {
+		n = it.batch
+	}
+	if cap(it.buf) < n {
+		it.buf = make([]T, n)
+	}
+	it.buf = it.buf[:n:n]
+	if err := it.t.Next(&it.buf); err != nil {
+		it.err = err
+		it.buf = it.buf[:0]
+		return false
+	}
+	it.pos = 0
+	return true
+}
+
+// Value returns the current packet. Next must have returned true.
+func (it *PacketIter[T]) Value() T {
+	return it.buf[it.pos]
+}
+
+// Batch returns the current packet and the following packets of the current
+// batch, and advances the iterator past them, so that the next call to Next
+// reads a new batch. The returned slice is only valid until that call.
+// Batch returns nil when there is no current packet, before the first call
+// to Next or after it returned false.
+func (it *PacketIter[T]) Batch() []T {
+	if it.pos < 0 || it.pos >= len(it.buf) {
+		return nil
+	}
+	b := it.buf[it.pos:]
+	it.pos = len(it.buf) - 1
+	return b
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *PacketIter[T]) Err() error {
+	return it.err
+}
+
+// All returns a function ranging over the packets of t of type T, from the
+// current index of t to its last packet, as an iter.Seq2[T, error] does.
+// Packets are read by chunk of the table. Iteration stops after the first
+// error, which is yielded with the zero value of T.
+func All[T any](t *Table) func(yield func(T, error) bool) {
+	return func(yield func(T, error) bool) {
+		it := Iter[T](t, 0)
+		for it.Next() {
+			if !yield(it.Value(), nil) {
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
+
+// remaining returns the number of packets from the current index of t to the
+// end of the table.
+func (t *Table) remaining() (int, error) {
+	var idx C.hsize_t
+	if err := h5err(C.H5PTget_index(t.id, &idx)); err != nil {
+		return 0, err
+	}
+	n, err := t.NumPackets()
+	if err != nil {
+		return 0, err
+	}
+	if int(idx) >= n {
+		return 0, nil
+	}
+	return n - int(idx), nil
+}
+
+// chunkSize returns the number of packets in a chunk of t, or
+// defaultPacketBatch if it is not known.
+func (t *Table) chunkSize() int {
+	if n := int(C._go_hdf5_pt_chunk_size(t.id)); n > 0 {
+		return n
+	}
+	return defaultPacketBatch
+}
@@ -0,0 +1,103 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hdf5
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestPacketIter(t *testing.T) {
+	f, err := CreateFile(fname, F_ACC_TRUNC)
+	if err != nil {
+		t.Fatalf("CreateFile failed: %s", err)
+	}
+	defer os.Remove(fname)
+	defer f.Close()
+
+	table, err := f.CreateTableFrom(tname, particle{}, chunkSize, compress)
+	if err != nil {
+		t.Fatalf("CreateTableFrom failed: %s", err)
+	}
+	defer table.Close()
+
+	var want []particle
+	for i := 0; i < 25; i++ {
+		p := particle{Name: string(rune('a' + i)), Lati: int32(i), Temperature: float64(i) / 2}
+		if err := table.Append(p); err != nil {
+			t.Fatalf("Append failed: %s", err)
+		}
+		want = append(want, p)
+	}
+
+	t.Run("Next", func(t *testing.T) {
+		if err := table.CreateIndex(); err != nil {
+			t.Fatalf("CreateIndex failed: %s", err)
+		}
+		var got []particle
+		it := Iter[particle](table, 7)
+		for it.Next() {
+			got = append(got, it.Value())
+		}
+		if err := it.Err(); err != nil {
+			t.Fatalf("iteration failed: %s", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("packets differ:\ngot= %v\nwant=%v", got, want)
+		}
+		if it.Next() {
+			t.Errorf("unexpected packet after the end of the table")
+		}
+	})
+
+	t.Run("Batch", func(t *testing.T) {
+		if err := table.CreateIndex(); err != nil {
+			t.Fatalf("CreateIndex failed: %s", err)
+		}
+		var (
+			got   []particle
+			sizes []int
+		)
+		it := Iter[particle](table, 10)
+		if b := it.Batch(); b != nil {
+			t.Errorf("unexpected batch before Next: %v", b)
+		}
+		for it.Next() {
+			b := it.Batch()
+			sizes = append(sizes, len(b))
+			got = append(got, b...)
+		}
+		if err := it.Err(); err != nil {
+			t.Fatalf("iteration failed: %s", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("packets differ:\ngot= %v\nwant=%v", got, want)
+		}
+		if wantSizes := []int{10, 10, 5}; !reflect.DeepEqual(sizes, wantSizes) {
+			t.Errorf("unexpected batch sizes: got %v, want %v", sizes, wantSizes)
+		}
+		if b := it.Batch(); b != nil {
+			t.Errorf("unexpected batch after the end of the table: %v", b)
+		}
+	})
+
+	t.Run("All", func(t *testing.T) {
+		if err := table.SetIndex(5); err != nil {
+			t.Fatalf("SetIndex failed: %s", err)
+		}
+		var got []particle
+		All[particle](table)(func(p particle, err error) bool {
+			if err != nil {
+				t.Fatalf("iteration failed: %s", err)
+			}
+			got = append(got, p)
+			return len(got) < 12
+		})
+		if !reflect.DeepEqual(got, want[5:17]) {
+			t.Fatalf("packets differ:\ngot= %v\nwant=%v", got, want[5:17])
+		}
+	})
+}
@@ -9,6 +9,62 @@ package hdf5
 // #include <stdint.h>
 // #include <stdlib.h>
 // #include <string.h>
+//
+// static inline herr_t _go_hdf5_pt_free_vlen(hid_t table, size_t n, void *buf) {
+// #if H5_VERSION_GE(1,10,0)
+//   return H5PTfree_vlen_buff(table, n, buf);
+// #else
+//   return H5PTfree_vlen_readbuff(table, n, buf);
+// #endif
+// }
+//
+// static inline int _go_hdf5_pt_has_ptcreate(void) {
+// #if H5_VERSION_GE(1,10,0)
+//   return 1;
+// #else
+//   return 0;
+// #endif
+// }
+//
+// static inline hid_t _go_hdf5_ptcreate(hid_t loc, const char *name, hid_t dtype, hsize_t chunk, hid_t plist) {
+// #if H5_VERSION_GE(1,10,0)
+//   return H5PTcreate(loc, name, dtype, chunk, plist);
+// #else
+//   return -1;
+// #endif
+// }
+//
+// static inline hid_t _go_hdf5_ptget_dataset(hid_t table) {
+// #if H5_VERSION_GE(1,10,0)
+//   return H5PTget_dataset(table);
+// #else
+//   return -1;
+// #endif
+// }
+//
+// static inline hid_t _go_hdf5_ptcreate_vl(hid_t loc, const char *name, hid_t base, hsize_t chunk, int compression) {
+// #if H5_VERSION_GE(1,10,0)
+//   hid_t vltype, plist, id;
+//   vltype = H5Tvlen_create(base);
+//   if (vltype < 0) {
+//     return -1;
+//   }
+//   plist = H5Pcreate(H5P_DATASET_CREATE);
+//   if (plist < 0) {
+//     H5Tclose(vltype);
+//     return -1;
+//   }
+//   id = -1;
+//   if (compression < 0 || H5Pset_deflate(plist, (unsigned)compression) >= 0) {
+//     id = H5PTcreate(loc, name, vltype, chunk, plist);
+//   }
+//   H5Pclose(plist);
+//   H5Tclose(vltype);
+//   return id;
+// #else
+//   return H5PTcreate_vl(loc, name, chunk);
+// #endif
+// }
 import "C"
 
 import (
@@ -30,6 +86,9 @@ func newPacketTable(id C.hid_t) *Table {
 
 // Close closes an open packet table.
 func (t *Table) Close() error {
+	h5lock()
+	defer h5unlock()
+
 	return t.closeWith(h5ptclose)
 }
 
@@ -39,11 +98,26 @@ func h5ptclose(id C.hid_t) C.herr_t {
 
 // IsValid returns whether or not an indentifier points to a packet table.
 func (t *Table) IsValid() bool {
+	h5lock()
+	defer h5unlock()
+
 	return C.H5PTis_valid(t.id) >= 0
 }
 
 // ReadPackets reads a number of packets from a packet table.
+//
+// Packets holding strings or variable-length sequences are decoded with
+// cmem.Decoder into newly allocated Go values, that do not reference the
+// memory allocated by HDF5.
+//
+// Packets can not be read into interface values, such as the elements of
+// a []interface{}, since their type is needed to decode them. Earlier
+// versions overwrote such values with the raw bytes of the packets, and
+// now return an error.
 func (t *Table) ReadPackets(start, nrecords int, data interface{}) error {
+	h5lock()
+	defer h5unlock()
+
 	c_start := C.hsize_t(start)
 	c_nrecords := C.size_t(nrecords)
 	rv := reflect.Indirect(reflect.ValueOf(data))
@@ -66,17 +140,31 @@ func (t *Table) ReadPackets(start, nrecords int, data interface{}) error {
 	default:
 		panic(fmt.Errorf("unhandled kind (%s), need slice or array", rt.Kind()))
 	}
+	if hasGoPointers(rt.Elem()) {
+		return t.readDecoded(rv, nrecords, func(buf unsafe.Pointer) C.herr_t {
+			return C.H5PTread_packets(t.id, c_start, c_nrecords, buf)
+		})
+	}
 	err := C.H5PTread_packets(t.id, c_start, c_nrecords, c_data)
 	return h5err(err)
 }
 
 // Append appends packets to the end of a packet table.
 //
+// Packets of a variable-length packet table are slices or arrays of the
+// packet elements, of any length.
+//
 // Struct values must only have exported fields, otherwise Append will panic.
 func (t *Table) Append(args ...interface{}) error {
+	h5lock()
+	defer h5unlock()
+
 	if len(args) == 0 {
 		return fmt.Errorf("hdf5: no arguments passed to packet table append.")
 	}
+	if t.IsVariableLength() {
+		return t.appendVarLen(args)
+	}
 
 	var enc cmem.Encoder
 	for _, arg := range args {
@@ -92,9 +180,58 @@ func (t *Table) Append(args ...interface{}) error {
 	return h5err(C.H5PTappend(t.id, C.size_t(len(args)), unsafe.Pointer(&enc.Buf[0])))
 }
 
+// appendVarLen appends the variable-length packets args to the table.
+func (t *Table) appendVarLen(args []interface{}) error {
+	n := len(args)
+	vls := (*C.hvl_t)(C.calloc(C.size_t(n), C.size_t(unsafe.Sizeof(C.hvl_t{}))))
+	if vls == nil {
+		return fmt.Errorf("hdf5: could not allocate %d packets", n)
+	}
+	defer C.free(unsafe.Pointer(vls))
+	seqs := unsafe.Slice(vls, n)
+	defer func() {
+		for _, seq := range seqs {
+			C.free(seq.p)
+		}
+	}()
+
+	for i, arg := range args {
+		rv := reflect.Indirect(reflect.ValueOf(arg))
+		if k := rv.Kind(); k != reflect.Slice && k != reflect.Array {
+			return fmt.Errorf("hdf5: invalid variable-length packet %d of kind %v, need slice or array", i, k)
+		}
+		if et := rv.Type().Elem(); hasGoPointers(et) {
+			// The encoded elements would hold Go pointers in C memory.
+			return fmt.Errorf("hdf5: invalid variable-length packet %d of %v elements holding Go pointers", i, et)
+		}
+		if rv.Len() == 0 {
+			continue
+		}
+		var enc cmem.Encoder
+		if err := enc.Encode(arg); err != nil {
+			return err
+		}
+		// The encoder does not pad the last element.
+		size := cmem.Sizeof(rv.Type().Elem())
+		seqs[i].p = C.calloc(C.size_t(rv.Len()), C.size_t(size))
+		if seqs[i].p == nil {
+			return fmt.Errorf("hdf5: could not allocate packet %d", i)
+		}
+		copy(unsafe.Slice((*byte)(seqs[i].p), rv.Len()*size), enc.Buf)
+		seqs[i].len = C.size_t(rv.Len())
+	}
+
+	return h5err(C.H5PTappend(t.id, C.size_t(n), unsafe.Pointer(vls)))
+}
+
 // Next reads packets from a packet table starting at the current index into the value pointed at by data.
 // i.e. data is a pointer to an array or a slice.
+// Packets are decoded as ReadPackets does, and can not be read into
+// interface values either.
 func (t *Table) Next(data interface{}) error {
+	h5lock()
+	defer h5unlock()
+
 	rt := reflect.TypeOf(data)
 	if rt.Kind() != reflect.Ptr {
 		return fmt.Errorf("hdf5: invalid value type. got=%v, want pointer", rt.Kind())
@@ -123,12 +260,96 @@ func (t *Table) Next(data interface{}) error {
 	default:
 		panic(fmt.Errorf("unsupported kind (%s), need slice or array", rt.Kind()))
 	}
+	if hasGoPointers(rt.Elem()) {
+		return t.readDecoded(rv, int(n), func(buf unsafe.Pointer) C.herr_t {
+			return C.H5PTget_next(t.id, n, buf)
+		})
+	}
 	err := C.H5PTget_next(t.id, n, cdata)
 	return h5err(err)
 }
 
+// IsVariableLength returns whether the table stores variable-length packets.
+func (t *Table) IsVariableLength() bool {
+	h5lock()
+	defer h5unlock()
+
+	return C.H5PTis_varlen(t.id) > 0
+}
+
+// FreeVLen releases the memory allocated by HDF5 for the variable-length
+// data of the n packets of the table stored in buf, such as the packets read
+// from C code. Packets read by ReadPackets and Next are released by them.
+func (t *Table) FreeVLen(buf unsafe.Pointer, n int) error {
+	h5lock()
+	defer h5unlock()
+
+	return h5err(C._go_hdf5_pt_free_vlen(t.id, C.size_t(n), buf))
+}
+
+// Dataset returns the dataset storing the packets of the table, to access
+// its attributes for instance. The returned dataset must be closed by the
+// user when it is no longer needed.
+//
+// Dataset requires HDF5 1.10 or later.
+func (t *Table) Dataset() (*Dataset, error) {
+	h5lock()
+	defer h5unlock()
+
+	if C._go_hdf5_pt_has_ptcreate() == 0 {
+		return nil, fmt.Errorf("hdf5: access to the dataset of a packet table requires HDF5 1.10 or later")
+	}
+	hid := C._go_hdf5_ptget_dataset(t.id)
+	if err := checkID(hid); err != nil {
+		return nil, err
+	}
+	// The identifier is owned by the table.
+	if err := h5err(C.H5Iinc_ref(hid)); err != nil {
+		return nil, err
+	}
+	return newDataset(hid, nil), nil
+}
+
+// readDecoded reads n packets with read into a C buffer, and decodes them
+// into the first n elements of the array or slice rv. The memory allocated
+// by HDF5 for strings and variable-length sequences is released afterwards.
+func (t *Table) readDecoded(rv reflect.Value, n int, read func(buf unsafe.Pointer) C.herr_t) error {
+	elem := rv.Type().Elem()
+	if elem.Kind() == reflect.Interface {
+		return fmt.Errorf("hdf5: can not read packets into %v values, need the packet type", elem)
+	}
+	if n == 0 {
+		return nil
+	}
+	size := cmem.Sizeof(elem)
+	buf := C.calloc(C.size_t(n), C.size_t(size))
+	if buf == nil {
+		return fmt.Errorf("hdf5: could not allocate %d packets", n)
+	}
+	defer C.free(buf)
+
+	if err := h5err(read(buf)); err != nil {
+		return err
+	}
+	defer t.FreeVLen(buf, n)
+
+	if rv.Kind() == reflect.Slice {
+		rv = rv.Slice(0, n)
+	}
+	dec := cmem.Decoder{Buf: unsafe.Slice((*byte)(buf), n*size)}
+	for i := 0; i < n; i++ {
+		if err := dec.Decode(rv.Index(i).Addr().Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // NumPackets returns the number of packets in a packet table.
 func (t *Table) NumPackets() (int, error) {
+	h5lock()
+	defer h5unlock()
+
 	c_nrecords := C.hsize_t(0)
 	err := C.H5PTget_num_packets(t.id, &c_nrecords)
 	return int(c_nrecords), h5err(err)
@@ -136,12 +357,18 @@ func (t *Table) NumPackets() (int, error) {
 
 // CreateIndex resets a packet table's index to the first packet.
 func (t *Table) CreateIndex() error {
+	h5lock()
+	defer h5unlock()
+
 	err := C.H5PTcreate_index(t.id)
 	return h5err(err)
 }
 
 // SetIndex sets a packet table's index.
 func (t *Table) SetIndex(index int) error {
+	h5lock()
+	defer h5unlock()
+
 	c_idx := C.hsize_t(index)
 	err := C.H5PTset_index(t.id, c_idx)
 	return h5err(err)
@@ -150,6 +377,9 @@ func (t *Table) SetIndex(index int) error {
 // Type returns an identifier for a copy of the datatype for a dataset. The returned
 // datatype must be closed by the user when it is no longer needed.
 func (t *Table) Type() (*Datatype, error) {
+	h5lock()
+	defer h5unlock()
+
 	hid := C.H5Dget_type(t.id)
 	if err := checkID(hid); err != nil {
 		return nil, err
@@ -170,6 +400,44 @@ func createTable(id C.hid_t, name string, dtype *Datatype, chunkSize, compressio
 	return newPacketTable(hid), nil
 }
 
+func createTableWith(id C.hid_t, name string, dtype *Datatype, chunkSize int, dcpl *PropList) (*Table, error) {
+	if C._go_hdf5_pt_has_ptcreate() == 0 {
+		return nil, fmt.Errorf("hdf5: packet tables with creation properties require HDF5 1.10 or later")
+	}
+	if dcpl == nil {
+		dcpl = P_DEFAULT
+	}
+
+	c_name := C.CString(name)
+	defer C.free(unsafe.Pointer(c_name))
+
+	hid := C._go_hdf5_ptcreate(id, c_name, dtype.id, C.hsize_t(chunkSize), dcpl.id)
+	if err := checkID(hid); err != nil {
+		return nil, err
+	}
+	return newPacketTable(hid), nil
+}
+
+func createVarLenTable(id C.hid_t, name string, dtype *Datatype, chunkSize, compression int) (*Table, error) {
+	if C._go_hdf5_pt_has_ptcreate() == 0 && !dtype.Equal(T_NATIVE_UCHAR) {
+		return nil, fmt.Errorf("hdf5: variable-length packet tables of non-byte elements require HDF5 1.10 or later")
+	}
+	if C._go_hdf5_pt_has_ptcreate() == 0 && compression >= 0 {
+		return nil, fmt.Errorf("hdf5: compressed variable-length packet tables require HDF5 1.10 or later")
+	}
+
+	c_name := C.CString(name)
+	defer C.free(unsafe.Pointer(c_name))
+
+	chunk := C.hsize_t(chunkSize)
+	compr := C.int(compression)
+	hid := C._go_hdf5_ptcreate_vl(id, c_name, dtype.id, chunk, compr)
+	if err := checkID(hid); err != nil {
+		return nil, err
+	}
+	return newPacketTable(hid), nil
+}
+
 func createTableFrom(id C.hid_t, name string, dtype interface{}, chunkSize, compression int) (*Table, error) {
 	var err error
 	switch dt := dtype.(type) {
@@ -18,7 +18,7 @@ const (
 )
 
 type particle struct {
-	// Name        string  `hdf5:"Name"` // FIXME(TacoVox): ReadPackets seems to need an update
+	Name        string  `hdf5:"Name"`
 	Vehicle_no  uint8   `hdf5:"Vehicle Number"`
 	Satellites  int8    `hdf5:"Satellites"`
 	Cars_no     int16   `hdf5:"Number of Cars"`
@@ -73,12 +73,22 @@ func testTable(t *testing.T, dType interface{}, data ...interface{}) {
 
 	// iterate through packets
 	for i := 0; i != n; i++ {
-		p := make([]interface{}, 1)
-		if err = table.Next(&p); err != nil {
+		p := reflect.New(reflect.SliceOf(reflect.TypeOf(data[0])))
+		p.Elem().Set(reflect.MakeSlice(p.Elem().Type(), 1, 1))
+		if err = table.Next(p.Interface()); err != nil {
 			t.Fatalf("Next failed for %s: %s", typeString, err)
 		}
 	}
 
+	// packets can not be read into interface values.
+	if err := table.SetIndex(0); err != nil {
+		t.Fatalf("SetIndex failed for %s: %s", typeString, err)
+	}
+	p := make([]interface{}, 1)
+	if err := table.Next(&p); err == nil {
+		t.Fatalf("expected an error reading packets into interface values for %s", typeString)
+	}
+
 	// For now just conduct the "old" test case // FIXME(TacoVox)
 	if reflect.TypeOf(data).String() != "[]hdf5.particle" {
 		return
@@ -108,17 +118,17 @@ func TestPTStruct(t *testing.T) {
 	// 	{"five", 50, 50, 5.0, 50., []int{0, 0}, [2][2]int{{5, 0}, {0, 5}}},
 	// 	{"six", 60, 60, 6.0, 60., []int{0, 0}, [2][2]int{{6, 0}, {0, 6}}},
 	// 	{"seven", 70, 70, 7.0, 70., []int{0, 0}, [2][2]int{{7, 0}, {0, 7}}},
-	// } // TODO use array and strings when read is fixed.
+	// } // TODO use arrays and slices when read is fixed.
 
-	testTable(t, particle{}, particle{0, 0, 0, 0, 0, 0, 0, 0, 0.0, 0.0, false})
+	testTable(t, particle{}, particle{"zero", 0, 0, 0, 0, 0, 0, 0, 0, 0.0, 0.0, false})
 	testTable(t, particle{},
-		particle{10, 10, 10, 10, 10, 10, 10, 10, 1.0, 10.0, false},
-		particle{20, 20, 20, 20, 20, 20, 20, 20, 2.0, 20.0, false},
-		particle{30, 30, 30, 30, 30, 30, 30, 30, 3.0, 30.0, false},
-		particle{40, 40, 40, 40, 40, 40, 40, 40, 4.0, 40.0, true},
-		particle{50, 50, 50, 50, 50, 50, 50, 50, 5.0, 50.0, true},
-		particle{60, 60, 60, 60, 60, 60, 60, 60, 6.0, 60.0, true},
-		particle{70, 70, 70, 70, 70, 70, 70, 70, 7.0, 70.0, true},
+		particle{"one", 10, 10, 10, 10, 10, 10, 10, 10, 1.0, 10.0, false},
+		particle{"two", 20, 20, 20, 20, 20, 20, 20, 20, 2.0, 20.0, false},
+		particle{"three", 30, 30, 30, 30, 30, 30, 30, 30, 3.0, 30.0, false},
+		particle{"four", 40, 40, 40, 40, 40, 40, 40, 40, 4.0, 40.0, true},
+		particle{"five", 50, 50, 50, 50, 50, 50, 50, 50, 5.0, 50.0, true},
+		particle{"six", 60, 60, 60, 60, 60, 60, 60, 60, 6.0, 60.0, true},
+		particle{"seven", 70, 70, 70, 70, 70, 70, 70, 70, 7.0, 70.0, true},
 	)
 }
 
@@ -267,3 +277,178 @@ func TestPTableBasic(t *testing.T) {
 		"seven",
 	)
 }
+
+func TestPTVarLen(t *testing.T) {
+	f, err := CreateFile(fname, F_ACC_TRUNC)
+	if err != nil {
+		t.Fatalf("CreateFile failed: %s", err)
+	}
+	defer os.Remove(fname)
+	defer f.Close()
+
+	table, err := f.CreateVarLenTable(tname, T_NATIVE_UINT8, chunkSize, -1)
+	if err != nil {
+		t.Fatalf("CreateVarLenTable failed: %s", err)
+	}
+	defer table.Close()
+
+	events := [][]byte{
+		[]byte("start"),
+		{},
+		[]byte("payload of a larger event"),
+		{0, 1, 2},
+	}
+	args := make([]interface{}, len(events))
+	for i, e := range events {
+		args[i] = e
+	}
+	if err := table.Append(args...); err != nil {
+		t.Fatalf("Append failed: %s", err)
+	}
+	if err := table.Append([2]byte{'e', 'n'}); err != nil {
+		t.Fatalf("Append of array failed: %s", err)
+	}
+	events = append(events, []byte("en"))
+	if err := table.Append(uint8(1)); err == nil {
+		t.Errorf("expected an error appending a scalar packet")
+	}
+	if err := table.Append([]string{"e", "n"}); err == nil {
+		t.Errorf("expected an error appending a packet of strings")
+	}
+
+	n, err := table.NumPackets()
+	if err != nil {
+		t.Fatalf("NumPackets failed: %s", err)
+	}
+	if n != len(events) {
+		t.Fatalf("wrong number of packets: got %d, want %d", n, len(events))
+	}
+
+	if err := table.ReadPackets(0, 0, &[][]byte{}); err != nil {
+		t.Fatalf("ReadPackets of no packets failed: %s", err)
+	}
+	got := make([][]byte, n)
+	if err := table.ReadPackets(0, n, &got); err != nil {
+		t.Fatalf("ReadPackets failed: %s", err)
+	}
+	if !reflect.DeepEqual(got, events) {
+		t.Fatalf("packets differ:\ngot= %q\nwant=%q", got, events)
+	}
+
+	if err := table.CreateIndex(); err != nil {
+		t.Fatalf("CreateIndex failed: %s", err)
+	}
+	for i, want := range events {
+		p := make([][]byte, 1)
+		if err := table.Next(&p); err != nil {
+			t.Fatalf("Next failed: %s", err)
+		}
+		if !reflect.DeepEqual(p[0], want) {
+			t.Errorf("packet %d differs: got %q, want %q", i, p[0], want)
+		}
+	}
+}
+
+func TestPTCreateWith(t *testing.T) {
+	f, err := CreateFile(fname, F_ACC_TRUNC)
+	if err != nil {
+		t.Fatalf("CreateFile failed: %s", err)
+	}
+	defer os.Remove(fname)
+	defer f.Close()
+
+	dcpl, err := NewPropList(P_DATASET_CREATE)
+	if err != nil {
+		t.Fatalf("NewPropList failed: %s", err)
+	}
+	defer dcpl.Close()
+	if err := dcpl.SetShuffle(); err != nil {
+		t.Fatalf("SetShuffle failed: %s", err)
+	}
+	if err := dcpl.SetFletcher32(); err != nil {
+		t.Fatalf("SetFletcher32 failed: %s", err)
+	}
+
+	table, err := f.CreateTableWith(tname, T_NATIVE_INT32, chunkSize, dcpl)
+	if err != nil {
+		t.Fatalf("CreateTableWith failed: %s", err)
+	}
+	defer table.Close()
+
+	if table.IsVariableLength() {
+		t.Errorf("fixed-length packet table reported as variable-length")
+	}
+
+	want := []int32{1, 2, 3, 5, 8, 13}
+	for _, v := range want {
+		if err := table.Append(v); err != nil {
+			t.Fatalf("Append failed: %s", err)
+		}
+	}
+	got := make([]int32, len(want))
+	if err := table.ReadPackets(0, len(got), &got); err != nil {
+		t.Fatalf("ReadPackets failed: %s", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("packets differ: got %v, want %v", got, want)
+	}
+
+	dset, err := table.Dataset()
+	if err != nil {
+		t.Fatalf("Dataset failed: %s", err)
+	}
+	defer dset.Close()
+	if name := dset.Name(); name != "/"+tname {
+		t.Errorf("unexpected dataset name: got %q, want %q", name, "/"+tname)
+	}
+	plist, err := dset.CreatePropList()
+	if err != nil {
+		t.Fatalf("CreatePropList failed: %s", err)
+	}
+	defer plist.Close()
+	n, err := plist.NFilters()
+	if err != nil {
+		t.Fatalf("NFilters failed: %s", err)
+	}
+	var filters []FilterID
+	for i := 0; i < n; i++ {
+		info, err := plist.Filter(i)
+		if err != nil {
+			t.Fatalf("Filter(%d) failed: %s", i, err)
+		}
+		filters = append(filters, info.ID)
+	}
+	if want := []FilterID{Z_FILTER_SHUFFLE, Z_FILTER_FLETCHER32}; !reflect.DeepEqual(filters, want) {
+		t.Errorf("unexpected filters of the table: got %v, want %v", filters, want)
+	}
+
+	space, err := CreateSimpleDataspace([]uint{1}, nil)
+	if err != nil {
+		t.Fatalf("CreateSimpleDataspace failed: %s", err)
+	}
+	defer space.Close()
+	attr, err := dset.CreateAttribute("scale", T_NATIVE_DOUBLE, space)
+	if err != nil {
+		t.Fatalf("CreateAttribute failed: %s", err)
+	}
+	if err := attr.Write(&[1]float64{0.5}, T_NATIVE_DOUBLE); err != nil {
+		t.Fatalf("attribute Write failed: %s", err)
+	}
+	attr.Close()
+	var scale float64
+	if err := f.ReadAttribute(tname, "scale", &scale); err != nil {
+		t.Fatalf("ReadAttribute failed: %s", err)
+	}
+	if scale != 0.5 {
+		t.Errorf("attribute of the table: got %v, want 0.5", scale)
+	}
+
+	vl, err := f.CreateVarLenTable("events", T_NATIVE_UINT8, chunkSize, compress)
+	if err != nil {
+		t.Fatalf("CreateVarLenTable failed: %s", err)
+	}
+	defer vl.Close()
+	if !vl.IsVariableLength() {
+		t.Errorf("variable-length packet table reported as fixed-length")
+	}
+}
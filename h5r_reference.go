@@ -0,0 +1,122 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hdf5
+
+// #include "hdf5.h"
+// #include <stdlib.h>
+//
+// static inline hid_t _go_hdf5_rdereference(hid_t obj, H5R_type_t type, const void *ref) {
+// #if H5_VERSION_GE(1,10,0)
+//   return H5Rdereference2(obj, H5P_DEFAULT, type, ref);
+// #else
+//   return H5Rdereference(obj, type, ref);
+// #endif
+// }
+import "C"
+
+import (
+	"fmt"
+	"reflect"
+	"unsafe"
+)
+
+// ObjectRef is a reference to an HDF5 object.
+// Its memory layout matches T_STD_REF_OBJ, so slices of ObjectRef can be
+// read from and written to datasets of that datatype.
+type ObjectRef uint64
+
+// RegionRef is a reference to a selection of a dataset.
+// Its memory layout matches T_STD_REF_DSETREG, so slices of RegionRef can
+// be read from and written to datasets of that datatype.
+type RegionRef [regionRefSize]byte
+
+const regionRefSize = C.sizeof_hdset_reg_ref_t
+
+var (
+	_go_object_ref_t = reflect.TypeOf(ObjectRef(0))
+	_go_region_ref_t = reflect.TypeOf(RegionRef{})
+)
+
+var (
+	_ Object = (*Group)(nil)
+	_ Object = (*Dataset)(nil)
+	_ Object = (*Datatype)(nil)
+)
+
+// CreateReference creates a reference to the object at path, relative
+// to the group.
+func (g *CommonFG) CreateReference(path string) (ObjectRef, error) {
+	h5lock()
+	defer h5unlock()
+
+	c_path := C.CString(path)
+	defer C.free(unsafe.Pointer(c_path))
+
+	var ref C.hobj_ref_t
+	err := h5err(C.H5Rcreate(unsafe.Pointer(&ref), g.id, c_path, C.H5R_OBJECT, -1))
+	return ObjectRef(ref), err
+}
+
+// CreateRegionReference creates a reference to the selection of space,
+// a dataspace of the dataset.
+func (s *Dataset) CreateRegionReference(space *Dataspace) (RegionRef, error) {
+	h5lock()
+	defer h5unlock()
+
+	var ref RegionRef
+	err := h5err(C.H5Rcreate(unsafe.Pointer(&ref[0]), s.id, cdot, C.H5R_DATASET_REGION, space.id))
+	return ref, err
+}
+
+// Dereference opens the object referenced by ref, in the file of the group.
+// The returned object is a *Group, a *Dataset or a *Datatype, and must be
+// closed by the user when it is no longer needed.
+func (g *CommonFG) Dereference(ref ObjectRef) (Object, error) {
+	h5lock()
+	defer h5unlock()
+
+	c_ref := C.hobj_ref_t(ref)
+	hid := C._go_hdf5_rdereference(g.id, C.H5R_OBJECT, unsafe.Pointer(&c_ref))
+	if err := checkID(hid); err != nil {
+		return nil, err
+	}
+	return newObject(hid)
+}
+
+// DereferenceRegion opens the dataset referenced by ref, in the file of the
+// group, and returns it along with a copy of its dataspace holding the
+// referenced selection. The returned dataset and dataspace must be closed
+// by the user when they are no longer needed.
+func (g *CommonFG) DereferenceRegion(ref RegionRef) (*Dataset, *Dataspace, error) {
+	h5lock()
+	defer h5unlock()
+
+	hid := C._go_hdf5_rdereference(g.id, C.H5R_DATASET_REGION, unsafe.Pointer(&ref[0]))
+	if err := checkID(hid); err != nil {
+		return nil, nil, err
+	}
+	dset := newDataset(hid, nil)
+	sid := C.H5Rget_region(hid, C.H5R_DATASET_REGION, unsafe.Pointer(&ref[0]))
+	if err := checkID(sid); err != nil {
+		dset.Close()
+		return nil, nil, err
+	}
+	return dset, newDataspace(sid), nil
+}
+
+// newObject wraps the identifier of an open object into its Go type.
+func newObject(hid C.hid_t) (Object, error) {
+	switch typ := IType(C.H5Iget_type(hid)); typ {
+	case GROUP:
+		return &Group{CommonFG{Identifier{hid}}}, nil
+	case DATASET:
+		return newDataset(hid, nil), nil
+	case DATATYPE:
+		return NewDatatype(hid), nil
+	default:
+		C.H5Oclose(hid)
+		return nil, fmt.Errorf("hdf5: unexpected object type %v", typ)
+	}
+}
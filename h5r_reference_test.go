@@ -0,0 +1,136 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hdf5
+
+import (
+	"os"
+	"testing"
+)
+
+func TestReferences(t *testing.T) {
+	f, err := CreateFile(fname, F_ACC_TRUNC)
+	if err != nil {
+		t.Fatalf("CreateFile failed: %s", err)
+	}
+	defer os.Remove(fname)
+	defer f.Close()
+
+	g, err := f.CreateGroup("grp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.Close()
+
+	data := make([]int32, 100)
+	for i := range data {
+		data[i] = int32(i)
+	}
+	dspace, err := CreateSimpleDataspace([]uint{10, 10}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dspace.Close()
+	dset, err := f.CreateDataset("data", T_NATIVE_INT32, dspace)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dset.Close()
+	if err := dset.Write(&data); err != nil {
+		t.Fatal(err)
+	}
+
+	// object references, stored in a dataset.
+	var refs [2]ObjectRef
+	for i, path := range []string{"grp", "/data"} {
+		refs[i], err = f.CreateReference(path)
+		if err != nil {
+			t.Fatalf("CreateReference(%q) failed: %s", path, err)
+		}
+	}
+	rtype, err := NewDatatypeFromValue(ObjectRef(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rtype.Close()
+	if !rtype.Equal(T_STD_REF_OBJ) {
+		t.Fatalf("invalid datatype for ObjectRef")
+	}
+	rspace, err := CreateSimpleDataspace([]uint{uint(len(refs))}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rspace.Close()
+	rset, err := f.CreateDataset("refs", rtype, rspace)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rset.Close()
+	if err := rset.Write(&refs); err != nil {
+		t.Fatal(err)
+	}
+	var got [2]ObjectRef
+	if err := rset.Read(&got); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, want := range []struct {
+		name string
+		typ  IType
+	}{{"/grp", GROUP}, {"/data", DATASET}} {
+		obj, err := f.Dereference(got[i])
+		if err != nil {
+			t.Fatalf("Dereference failed: %s", err)
+		}
+		if name := obj.Name(); name != want.name {
+			t.Errorf("invalid dereferenced object: got %q, want %q", name, want.name)
+		}
+		switch obj.(type) {
+		case *Group:
+			if want.typ != GROUP {
+				t.Errorf("unexpected group for %q", want.name)
+			}
+		case *Dataset:
+			if want.typ != DATASET {
+				t.Errorf("unexpected dataset for %q", want.name)
+			}
+		default:
+			t.Errorf("unexpected object type %T", obj)
+		}
+		obj.Close()
+	}
+
+	// region references.
+	sel := dset.Space()
+	defer sel.Close()
+	if err := sel.SelectHyperslab([]uint{2, 3}, nil, []uint{2, 4}, nil); err != nil {
+		t.Fatal(err)
+	}
+	rref, err := dset.CreateRegionReference(sel)
+	if err != nil {
+		t.Fatalf("CreateRegionReference failed: %s", err)
+	}
+	rdset, rsel, err := f.DereferenceRegion(rref)
+	if err != nil {
+		t.Fatalf("DereferenceRegion failed: %s", err)
+	}
+	defer rdset.Close()
+	defer rsel.Close()
+	if name := rdset.Name(); name != "/data" {
+		t.Fatalf("invalid dereferenced dataset: got %q", name)
+	}
+	memspace, err := CreateSimpleDataspace([]uint{2, 4}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer memspace.Close()
+	var region [8]int32
+	if err := rdset.ReadSubset(&region, memspace, rsel); err != nil {
+		t.Fatal(err)
+	}
+	want := [8]int32{23, 24, 25, 26, 33, 34, 35, 36}
+	if region != want {
+		t.Fatalf("invalid region: got=%v, want=%v", region, want)
+	}
+}
@@ -7,6 +7,8 @@ package hdf5
 // #include "hdf5.h"
 // #include <stdlib.h>
 // #include <string.h>
+//
+// static inline hsize_t _go_hdf5_H5S_UNLIMITED(void) { return H5S_UNLIMITED; }
 import "C"
 
 import (
@@ -29,6 +31,39 @@ const (
 	S_NULL     SpaceClass = 2  // null data space
 )
 
+// SelectOp is the operation used to combine a new selection with the
+// current selection of a dataspace.
+type SelectOp C.H5S_seloper_t
+
+const (
+	S_SELECT_SET     SelectOp = C.H5S_SELECT_SET     // Replace the current selection
+	S_SELECT_OR      SelectOp = C.H5S_SELECT_OR      // Union of the current and new selections
+	S_SELECT_AND     SelectOp = C.H5S_SELECT_AND     // Intersection of the current and new selections
+	S_SELECT_XOR     SelectOp = C.H5S_SELECT_XOR     // Symmetric difference of the current and new selections
+	S_SELECT_NOTB    SelectOp = C.H5S_SELECT_NOTB    // Current selection minus the new selection
+	S_SELECT_NOTA    SelectOp = C.H5S_SELECT_NOTA    // New selection minus the current selection
+	S_SELECT_APPEND  SelectOp = C.H5S_SELECT_APPEND  // Append elements to the current point selection
+	S_SELECT_PREPEND SelectOp = C.H5S_SELECT_PREPEND // Prepend elements to the current point selection
+)
+
+// SelectionType is the type of the selection of a dataspace.
+type SelectionType C.H5S_sel_type
+
+const (
+	S_SEL_NONE       SelectionType = C.H5S_SEL_NONE       // Nothing selected
+	S_SEL_POINTS     SelectionType = C.H5S_SEL_POINTS     // Points selected
+	S_SEL_HYPERSLABS SelectionType = C.H5S_SEL_HYPERSLABS // Hyperslabs selected
+	S_SEL_ALL        SelectionType = C.H5S_SEL_ALL        // Entire extent selected
+)
+
+// S_UNLIMITED is the value of a maximum dimension size which may grow without bound.
+// It can only be used with chunked datasets.
+// It is converted to and from the H5S_UNLIMITED value of the library, whatever
+// the size of uint.
+const S_UNLIMITED uint = ^uint(0)
+
+var h5s_UNLIMITED = C._go_hdf5_H5S_UNLIMITED()
+
 func newDataspace(id C.hid_t) *Dataspace {
 	return &Dataspace{Identifier{id}}
 }
@@ -36,6 +71,9 @@ func newDataspace(id C.hid_t) *Dataspace {
 // CreateDataspace creates a new dataspace of a specified type. The returned
 // dataspace must be closed by the user when it is no longer needed.
 func CreateDataspace(class SpaceClass) (*Dataspace, error) {
+	h5lock()
+	defer h5unlock()
+
 	hid := C.H5Screate(C.H5S_class_t(class))
 	if err := checkID(hid); err != nil {
 		return nil, err
@@ -47,6 +85,9 @@ func CreateDataspace(class SpaceClass) (*Dataspace, error) {
 // Copy creates an exact copy of a dataspace. The returned dataspace must
 // be closed by the user when it is no longer needed.
 func (s *Dataspace) Copy() (*Dataspace, error) {
+	h5lock()
+	defer h5unlock()
+
 	hid := C.H5Scopy(s.id)
 	if err := checkID(hid); err != nil {
 		return nil, err
@@ -56,6 +97,9 @@ func (s *Dataspace) Copy() (*Dataspace, error) {
 
 // Close releases and terminates access to a dataspace.
 func (s *Dataspace) Close() error {
+	h5lock()
+	defer h5unlock()
+
 	return s.closeWith(h5sclose)
 }
 
@@ -66,6 +110,9 @@ func h5sclose(id C.hid_t) C.herr_t {
 // CreateSimpleDataspace creates a new simple dataspace and opens it for access.
 // The returned dataspace must be closed by the user when it is no longer needed.
 func CreateSimpleDataspace(dims, maxDims []uint) (*Dataspace, error) {
+	h5lock()
+	defer h5unlock()
+
 	var c_dims, c_maxdims *C.hsize_t
 
 	rank := C.int(0)
@@ -76,8 +123,7 @@ func CreateSimpleDataspace(dims, maxDims []uint) (*Dataspace, error) {
 	}
 	if maxDims != nil {
 		rank = C.int(len(maxDims))
-		c_maxdims = (*C.hsize_t)(unsafe.Pointer(&maxDims[0]))
-
+		c_maxdims = &maxDimsToHsize(maxDims)[0]
 	}
 	if len(dims) != len(maxDims) && (dims != nil && maxDims != nil) {
 		return nil, errors.New("lengths of dims and maxDims do not match")
@@ -92,11 +138,17 @@ func CreateSimpleDataspace(dims, maxDims []uint) (*Dataspace, error) {
 
 // IsSimple returns whether a dataspace is a simple dataspace.
 func (s *Dataspace) IsSimple() bool {
+	h5lock()
+	defer h5unlock()
+
 	return int(C.H5Sis_simple(s.id)) > 0
 }
 
 // SetOffset sets the offset of a simple dataspace.
 func (s *Dataspace) SetOffset(offset []uint) error {
+	h5lock()
+	defer h5unlock()
+
 	rank := len(offset)
 	if rank == 0 {
 		err := C.H5Soffset_simple(s.id, nil)
@@ -114,15 +166,33 @@ func (s *Dataspace) SetOffset(offset []uint) error {
 
 // SelectHyperslab creates a subset of the data space.
 func (s *Dataspace) SelectHyperslab(offset, stride, count, block []uint) error {
-	rank := len(offset)
-	if rank == 0 {
+	h5lock()
+	defer h5unlock()
+
+	if len(offset) == 0 {
 		err := C.H5Soffset_simple(s.id, nil)
 		return h5err(err)
 	}
-	if rank != s.SimpleExtentNDims() {
+	return s.SelectHyperslabOp(S_SELECT_SET, offset, stride, count, block)
+}
+
+// SelectHyperslabOp combines the hyperslab described by offset, stride,
+// count and block with the current selection of the dataspace, using op.
+// stride and block may be nil, in which case they default to 1.
+func (s *Dataspace) SelectHyperslabOp(op SelectOp, offset, stride, count, block []uint) error {
+	h5lock()
+	defer h5unlock()
+
+	rank := len(offset)
+	if rank == 0 || rank != s.SimpleExtentNDims() {
 		err := errors.New("size of offset does not match extent")
 		return err
 	}
+	if len(count) != rank ||
+		(stride != nil && len(stride) != rank) ||
+		(block != nil && len(block) != rank) {
+		return errors.New("sizes of hyperslab parameters do not match extent")
+	}
 
 	c_offset := (*C.hsize_t)(unsafe.Pointer(&offset[0]))
 	c_count := (*C.hsize_t)(unsafe.Pointer(&count[0]))
@@ -133,34 +203,230 @@ func (s *Dataspace) SelectHyperslab(offset, stride, count, block []uint) error {
 	if block != nil {
 		c_block = (*C.hsize_t)(unsafe.Pointer(&block[0]))
 	}
-	err := C.H5Sselect_hyperslab(s.id, C.H5S_SELECT_SET, c_offset, c_stride, c_count, c_block)
+	err := C.H5Sselect_hyperslab(s.id, C.H5S_seloper_t(op), c_offset, c_stride, c_count, c_block)
+	return h5err(err)
+}
+
+// SelectElements combines the points at coords with the current selection
+// of the dataspace, using op, which must be one of S_SELECT_SET,
+// S_SELECT_APPEND or S_SELECT_PREPEND. Each coordinate holds one value
+// per dimension of the dataspace. The order of the points defines the
+// order in which they are transferred.
+func (s *Dataspace) SelectElements(op SelectOp, coords [][]uint) error {
+	h5lock()
+	defer h5unlock()
+
+	if len(coords) == 0 {
+		return errors.New("no elements to select")
+	}
+	rank := s.SimpleExtentNDims()
+	if rank <= 0 {
+		return errors.New("can not select elements of a dataspace without dimensions")
+	}
+	c_coords := make([]C.hsize_t, 0, len(coords)*rank)
+	for i, coord := range coords {
+		if len(coord) != rank {
+			return fmt.Errorf("size of coordinate %d does not match extent", i)
+		}
+		for _, v := range coord {
+			c_coords = append(c_coords, C.hsize_t(v))
+		}
+	}
+	err := C.H5Sselect_elements(s.id, C.H5S_seloper_t(op), C.size_t(len(coords)), &c_coords[0])
 	return h5err(err)
 }
 
+// SelectAll selects the entire extent of the dataspace.
+func (s *Dataspace) SelectAll() error {
+	h5lock()
+	defer h5unlock()
+
+	return h5err(C.H5Sselect_all(s.id))
+}
+
+// SelectNone resets the selection of the dataspace to an empty selection.
+func (s *Dataspace) SelectNone() error {
+	h5lock()
+	defer h5unlock()
+
+	return h5err(C.H5Sselect_none(s.id))
+}
+
+// SelectValid returns whether the selection of the dataspace is within
+// its extent, taking the offset into account.
+func (s *Dataspace) SelectValid() bool {
+	h5lock()
+	defer h5unlock()
+
+	return C.H5Sselect_valid(s.id) > 0
+}
+
+// SelectNPoints returns the number of elements in the selection of the dataspace.
+func (s *Dataspace) SelectNPoints() int {
+	h5lock()
+	defer h5unlock()
+
+	return int(C.H5Sget_select_npoints(s.id))
+}
+
+// SelectType returns the type of the selection of the dataspace.
+func (s *Dataspace) SelectType() SelectionType {
+	h5lock()
+	defer h5unlock()
+
+	return SelectionType(C.H5Sget_select_type(s.id))
+}
+
+// SelectBounds returns the coordinates of the bounding box of the selection
+// of the dataspace. end holds the coordinates of the last selected element.
+func (s *Dataspace) SelectBounds() (start, end []uint, err error) {
+	h5lock()
+	defer h5unlock()
+
+	rank := s.SimpleExtentNDims()
+	if rank <= 0 {
+		return nil, nil, errors.New("no bounds for a dataspace without dimensions")
+	}
+	c_start := make([]C.hsize_t, rank)
+	c_end := make([]C.hsize_t, rank)
+	if err := h5err(C.H5Sget_select_bounds(s.id, &c_start[0], &c_end[0])); err != nil {
+		return nil, nil, err
+	}
+	start = make([]uint, rank)
+	end = make([]uint, rank)
+	for i := range start {
+		start[i] = uint(c_start[i])
+		end[i] = uint(c_end[i])
+	}
+	return start, end, nil
+}
+
+// HyperslabBlocks returns the blocks of the hyperslab selection of the
+// dataspace, as the coordinates of the start and end of each block.
+func (s *Dataspace) HyperslabBlocks() (start, end [][]uint, err error) {
+	h5lock()
+	defer h5unlock()
+
+	rank := s.SimpleExtentNDims()
+	n := int(C.H5Sget_select_hyper_nblocks(s.id))
+	if n < 0 {
+		return nil, nil, h5err(C.herr_t(n))
+	}
+	if n == 0 || rank <= 0 {
+		return nil, nil, nil
+	}
+	buf := make([]C.hsize_t, 2*rank*n)
+	if err := h5err(C.H5Sget_select_hyper_blocklist(s.id, 0, C.hsize_t(n), &buf[0])); err != nil {
+		return nil, nil, err
+	}
+	start = make([][]uint, n)
+	end = make([][]uint, n)
+	for i := range start {
+		start[i] = hsizeToUint(buf[2*i*rank : (2*i+1)*rank])
+		end[i] = hsizeToUint(buf[(2*i+1)*rank : (2*i+2)*rank])
+	}
+	return start, end, nil
+}
+
+// ElementPoints returns the coordinates of the points of the element
+// selection of the dataspace, in selection order.
+func (s *Dataspace) ElementPoints() ([][]uint, error) {
+	h5lock()
+	defer h5unlock()
+
+	rank := s.SimpleExtentNDims()
+	n := int(C.H5Sget_select_elem_npoints(s.id))
+	if n < 0 {
+		return nil, h5err(C.herr_t(n))
+	}
+	if n == 0 || rank <= 0 {
+		return nil, nil
+	}
+	buf := make([]C.hsize_t, rank*n)
+	if err := h5err(C.H5Sget_select_elem_pointlist(s.id, 0, C.hsize_t(n), &buf[0])); err != nil {
+		return nil, err
+	}
+	points := make([][]uint, n)
+	for i := range points {
+		points[i] = hsizeToUint(buf[i*rank : (i+1)*rank])
+	}
+	return points, nil
+}
+
+func hsizeToUint(vs []C.hsize_t) []uint {
+	o := make([]uint, len(vs))
+	for i, v := range vs {
+		o[i] = uint(v)
+	}
+	return o
+}
+
 // SimpleExtentDims returns dataspace dimension size and maximum size.
 func (s *Dataspace) SimpleExtentDims() (dims, maxdims []uint, err error) {
-	rank := s.SimpleExtentNDims()
+	h5lock()
+	defer h5unlock()
+
+	rank := int(C.H5Sget_simple_extent_ndims(s.id))
+	if rank < 0 {
+		return nil, nil, h5err(C.herr_t(rank))
+	}
 	dims = make([]uint, rank)
 	maxdims = make([]uint, rank)
+	if rank == 0 {
+		return dims, maxdims, nil
+	}
 
-	c_dims := (*C.hsize_t)(unsafe.Pointer(&dims[0]))
-	c_maxdims := (*C.hsize_t)(unsafe.Pointer(&maxdims[0]))
-	rc := C.H5Sget_simple_extent_dims(s.id, c_dims, c_maxdims)
+	c_dims := make([]C.hsize_t, rank)
+	c_maxdims := make([]C.hsize_t, rank)
+	rc := C.H5Sget_simple_extent_dims(s.id, &c_dims[0], &c_maxdims[0])
 	err = h5err(C.herr_t(rc))
+	for i, d := range c_dims {
+		dims[i] = uint(d)
+	}
+	for i, d := range c_maxdims {
+		if d == h5s_UNLIMITED {
+			maxdims[i] = S_UNLIMITED
+		} else {
+			maxdims[i] = uint(d)
+		}
+	}
 	return
 }
 
+// maxDimsToHsize returns the maximum dimension sizes dims as hsize_t values,
+// with S_UNLIMITED converted to H5S_UNLIMITED.
+func maxDimsToHsize(dims []uint) []C.hsize_t {
+	c_dims := make([]C.hsize_t, len(dims))
+	for i, d := range dims {
+		if d == S_UNLIMITED {
+			c_dims[i] = h5s_UNLIMITED
+		} else {
+			c_dims[i] = C.hsize_t(d)
+		}
+	}
+	return c_dims
+}
+
 // SimpleExtentNDims returns the dimensionality of a dataspace.
 func (s *Dataspace) SimpleExtentNDims() int {
+	h5lock()
+	defer h5unlock()
+
 	return int(C.H5Sget_simple_extent_ndims(s.id))
 }
 
 // SimpleExtentNPoints returns the number of elements in a dataspace.
 func (s *Dataspace) SimpleExtentNPoints() int {
+	h5lock()
+	defer h5unlock()
+
 	return int(C.H5Sget_simple_extent_npoints(s.id))
 }
 
 // SimpleExtentType returns the current class of a dataspace.
 func (s *Dataspace) SimpleExtentType() SpaceClass {
+	h5lock()
+	defer h5unlock()
+
 	return SpaceClass(C.H5Sget_simple_extent_type(s.id))
 }
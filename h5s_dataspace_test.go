@@ -5,6 +5,7 @@
 package hdf5
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -103,3 +104,104 @@ func arrayEq(a, b []uint) bool {
 	}
 	return true
 }
+
+func TestSelections(t *testing.T) {
+	space, err := CreateSimpleDataspace([]uint{10, 10}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer space.Close()
+
+	if got := space.SelectType(); got != S_SEL_ALL {
+		t.Fatalf("default selection: got %d, want %d", got, S_SEL_ALL)
+	}
+	if n := space.SelectNPoints(); n != 100 {
+		t.Fatalf("SelectNPoints: got %d, want 100", n)
+	}
+
+	if err := space.SelectNone(); err != nil {
+		t.Fatalf("SelectNone failed: %s", err)
+	}
+	if n := space.SelectNPoints(); n != 0 {
+		t.Fatalf("SelectNPoints after SelectNone: got %d, want 0", n)
+	}
+
+	// two overlapping 3x3 blocks.
+	if err := space.SelectHyperslabOp(S_SELECT_SET, []uint{0, 0}, nil, []uint{3, 3}, nil); err != nil {
+		t.Fatalf("SelectHyperslabOp(SET) failed: %s", err)
+	}
+	if err := space.SelectHyperslabOp(S_SELECT_OR, []uint{2, 2}, nil, []uint{3, 3}, nil); err != nil {
+		t.Fatalf("SelectHyperslabOp(OR) failed: %s", err)
+	}
+	if n := space.SelectNPoints(); n != 17 {
+		t.Fatalf("SelectNPoints of union: got %d, want 17", n)
+	}
+	start, end, err := space.SelectBounds()
+	if err != nil {
+		t.Fatalf("SelectBounds failed: %s", err)
+	}
+	if !reflect.DeepEqual(start, []uint{0, 0}) || !reflect.DeepEqual(end, []uint{4, 4}) {
+		t.Fatalf("SelectBounds: got %v %v", start, end)
+	}
+	bstart, bend, err := space.HyperslabBlocks()
+	if err != nil {
+		t.Fatalf("HyperslabBlocks failed: %s", err)
+	}
+	if len(bstart) == 0 || len(bstart) != len(bend) {
+		t.Fatalf("invalid hyperslab blocks: %v %v", bstart, bend)
+	}
+	if err := space.SelectHyperslabOp(S_SELECT_AND, []uint{2, 2}, nil, []uint{1, 1}, nil); err != nil {
+		t.Fatalf("SelectHyperslabOp(AND) failed: %s", err)
+	}
+	if n := space.SelectNPoints(); n != 1 {
+		t.Fatalf("SelectNPoints of intersection: got %d, want 1", n)
+	}
+	if err := space.SelectHyperslabOp(S_SELECT_SET, []uint{0}, nil, []uint{1}, nil); err == nil {
+		t.Fatalf("expected an error for a rank mismatch")
+	}
+
+	coords := [][]uint{{9, 9}, {0, 1}, {5, 3}}
+	if err := space.SelectElements(S_SELECT_SET, coords); err != nil {
+		t.Fatalf("SelectElements failed: %s", err)
+	}
+	if err := space.SelectElements(S_SELECT_APPEND, [][]uint{{1, 1}}); err != nil {
+		t.Fatalf("SelectElements(APPEND) failed: %s", err)
+	}
+	if got := space.SelectType(); got != S_SEL_POINTS {
+		t.Fatalf("point selection: got %d, want %d", got, S_SEL_POINTS)
+	}
+	if !space.SelectValid() {
+		t.Fatalf("point selection should be valid")
+	}
+	points, err := space.ElementPoints()
+	if err != nil {
+		t.Fatalf("ElementPoints failed: %s", err)
+	}
+	want := append(coords, []uint{1, 1})
+	if !reflect.DeepEqual(points, want) {
+		t.Fatalf("ElementPoints: got %v, want %v", points, want)
+	}
+	if err := space.SelectElements(S_SELECT_SET, [][]uint{{1}}); err == nil {
+		t.Fatalf("expected an error for a coordinate rank mismatch")
+	}
+
+	if err := space.SelectElements(S_SELECT_SET, [][]uint{{9, 0}}); err != nil {
+		t.Fatalf("SelectElements failed: %s", err)
+	}
+	if err := space.SetOffset([]uint{1, 0}); err != nil {
+		t.Fatal(err)
+	}
+	if space.SelectValid() {
+		t.Fatalf("out of extent selection should be invalid")
+	}
+	if err := space.SetOffset(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := space.SelectAll(); err != nil {
+		t.Fatalf("SelectAll failed: %s", err)
+	}
+	if n := space.SelectNPoints(); n != 100 {
+		t.Fatalf("SelectNPoints after SelectAll: got %d, want 100", n)
+	}
+}
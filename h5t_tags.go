@@ -0,0 +1,315 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hdf5
+
+// #include "hdf5.h"
+import "C"
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// fieldTag holds the options of the hdf5 struct tag of a field, of the form
+//
+//	Field T `hdf5:"name,option1,option2"`
+//
+// An empty name stands for the name of the field. The "-" tag skips the field.
+// The supported options are:
+//
+//	type=<datatype>  datatype of the field in the file, see parseTypeSpec
+//	pack             removes the padding of a struct field in the file
+type fieldTag struct {
+	name  string // name of the compound member
+	skip  bool   // whether the field is not a compound member
+	ftype string // datatype of the member in the file, if not empty
+	pack  bool   // whether the member is packed in the file
+}
+
+// parseFieldTag returns the options of the struct tag of f.
+//
+// For backward compatibility, a tag that is not in the conventional
+// key:"value" format is used as the member name.
+func parseFieldTag(f reflect.StructField) (fieldTag, error) {
+	tag := fieldTag{name: f.Name}
+	value, ok := f.Tag.Lookup("hdf5")
+	if !ok {
+		if raw := string(f.Tag); raw != "" && !strings.Contains(raw, `:"`) {
+			tag.name = raw
+		}
+		return tag, nil
+	}
+	if value == "-" {
+		tag.skip = true
+		return tag, nil
+	}
+
+	opts := strings.Split(value, ",")
+	if opts[0] != "" {
+		tag.name = opts[0]
+	}
+	for _, opt := range opts[1:] {
+		switch {
+		case opt == "pack":
+			tag.pack = true
+		case strings.HasPrefix(opt, "type="):
+			tag.ftype = strings.TrimPrefix(opt, "type=")
+			if tag.ftype == "" {
+				return tag, fmt.Errorf("hdf5: empty type in struct tag of field %s", f.Name)
+			}
+		default:
+			return tag, fmt.Errorf("hdf5: invalid option %q in struct tag of field %s", opt, f.Name)
+		}
+	}
+	return tag, nil
+}
+
+// typeSpecs are the short names of datatypes accepted by parseTypeSpec.
+var typeSpecs = map[string]*Datatype{
+	"i8":    T_NATIVE_INT8,
+	"i8le":  T_STD_I8LE,
+	"i8be":  T_STD_I8BE,
+	"i16":   T_NATIVE_INT16,
+	"i16le": T_STD_I16LE,
+	"i16be": T_STD_I16BE,
+	"i32":   T_NATIVE_INT32,
+	"i32le": T_STD_I32LE,
+	"i32be": T_STD_I32BE,
+	"i64":   T_NATIVE_INT64,
+	"i64le": T_STD_I64LE,
+	"i64be": T_STD_I64BE,
+	"u8":    T_NATIVE_UINT8,
+	"u8le":  T_STD_U8LE,
+	"u8be":  T_STD_U8BE,
+	"u16":   T_NATIVE_UINT16,
+	"u16le": T_STD_U16LE,
+	"u16be": T_STD_U16BE,
+	"u32":   T_NATIVE_UINT32,
+	"u32le": T_STD_U32LE,
+	"u32be": T_STD_U32BE,
+	"u64":   T_NATIVE_UINT64,
+	"u64le": T_STD_U64LE,
+	"u64be": T_STD_U64BE,
+	"f32":   T_NATIVE_FLOAT,
+	"f32le": T_IEEE_F32LE,
+	"f32be": T_IEEE_F32BE,
+	"f64":   T_NATIVE_DOUBLE,
+	"f64le": T_IEEE_F64LE,
+	"f64be": T_IEEE_F64BE,
+}
+
+// parseTypeSpec returns a copy of the datatype described by spec, either
+// a short name such as "i32", "u16be" or "f32le", or a datatype in the HDF5
+// DDL text format such as "H5T_STD_I32BE".
+func parseTypeSpec(spec string) (*Datatype, error) {
+	if dt, ok := typeSpecs[spec]; ok {
+		return dt.Copy()
+	}
+	return ParseDatatype(spec)
+}
+
+// NewFileDataTypeFromType creates a datatype to store values of the Go type
+// t in a file. Unlike NewDataTypeFromType, which creates the datatype of
+// values in memory, it honors the file options of the hdf5 struct tags of
+// struct fields:
+//
+//	type=<datatype>  sets the datatype of the field in the file, as a short
+//	                 name such as "i16", "u32be" or "f32le", or as a
+//	                 datatype in the HDF5 DDL text format
+//	pack             removes the padding of a struct field in the file
+//
+// Data is transferred between a Go value and a dataset or an attribute
+// created with such a datatype with a memory datatype created by
+// NewDataTypeFromType, as Dataset.Read, Dataset.Write, ReadAll and Write do,
+// and is converted by HDF5.
+func NewFileDataTypeFromType(t reflect.Type) (*Datatype, error) {
+	h5lock()
+	defer h5unlock()
+
+	return fileTypeBuilder{}.build(t)
+}
+
+// NewPackedDataTypeFromType creates a portable datatype to store values of the
+// Go type t in a file, as NewFileDataTypeFromType does, but with integer and
+// floating-point values stored in the byte order order, T_ORDER_LE or
+// T_ORDER_BE, and compound datatypes packed. Fields with an explicit type
+// in their hdf5 struct tag keep that type.
+func NewPackedDataTypeFromType(t reflect.Type, order ByteOrder) (*Datatype, error) {
+	h5lock()
+	defer h5unlock()
+
+	b := fileTypeBuilder{pack: true}
+	switch order {
+	case T_ORDER_LE:
+		b.suffix = "le"
+	case T_ORDER_BE:
+		b.suffix = "be"
+	default:
+		return nil, fmt.Errorf("hdf5: invalid byte order %d for a packed datatype", order)
+	}
+	return b.build(t)
+}
+
+// fileTypeBuilder builds file datatypes from Go types.
+type fileTypeBuilder struct {
+	suffix string // byte order suffix of the typeSpecs of atomic types, native if empty
+	pack   bool   // whether compound datatypes are packed
+}
+
+func (b fileTypeBuilder) build(t reflect.Type) (*Datatype, error) {
+	switch {
+	case t == _go_object_ref_t || t == _go_region_ref_t:
+		return NewDataTypeFromType(t)
+	case reflect.PtrTo(t).Implements(_go_enum_t):
+		if b.suffix == "" {
+			return NewDataTypeFromType(t)
+		}
+		base, err := b.atomic(t)
+		if err != nil {
+			return nil, err
+		}
+		defer base.Close()
+		return newEnumTypeWithBase(t, base)
+	}
+
+	switch t.Kind() {
+	case reflect.Array:
+		elem, err := b.build(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		defer elem.Close()
+		adt, err := NewArrayType(elem, getArrayDims(t))
+		if err != nil {
+			return nil, err
+		}
+		return &adt.Datatype, nil
+
+	case reflect.Slice:
+		elem, err := b.build(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		defer elem.Close()
+		vdt, err := NewVarLenType(elem)
+		if err != nil {
+			return nil, err
+		}
+		return &vdt.Datatype, nil
+
+	case reflect.Struct:
+		cdt, err := NewCompoundType(int(t.Size()))
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < t.NumField(); i++ {
+			if err := b.insertField(cdt, t.Field(i)); err != nil {
+				cdt.Close()
+				return nil, err
+			}
+		}
+		if b.pack {
+			if err := cdt.Pack(); err != nil {
+				cdt.Close()
+				return nil, err
+			}
+		}
+		return &cdt.Datatype, nil
+
+	case reflect.Ptr:
+		return b.build(t.Elem())
+
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		if b.suffix == "" {
+			return NewDataTypeFromType(t)
+		}
+		return b.atomic(t)
+
+	default:
+		return NewDataTypeFromType(t)
+	}
+}
+
+// atomic returns the integer or floating-point datatype with the byte order
+// of b and the size of the memory datatype of t.
+func (b fileTypeBuilder) atomic(t reflect.Type) (*Datatype, error) {
+	mt, err := NewDataTypeFromType(t)
+	if err != nil {
+		return nil, err
+	}
+	size := mt.Size()
+	mt.Close()
+
+	var prefix string
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		prefix = "i"
+	case reflect.Bool, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		prefix = "u"
+	case reflect.Float32, reflect.Float64:
+		prefix = "f"
+	}
+	dt, ok := typeSpecs[fmt.Sprintf("%s%d%s", prefix, 8*size, b.suffix)]
+	if !ok {
+		return nil, fmt.Errorf("hdf5: no %s datatype for %v", b.suffix, t)
+	}
+	return dt.Copy()
+}
+
+// insertField inserts the file datatype of the struct field f into cdt.
+func (b fileTypeBuilder) insertField(cdt *CompoundType, f reflect.StructField) error {
+	tag, err := parseFieldTag(f)
+	if err != nil || tag.skip {
+		return err
+	}
+
+	var dt *Datatype
+	if tag.ftype != "" {
+		dt, err = fieldFileType(f, tag.ftype)
+	} else {
+		dt, err = b.build(f.Type)
+	}
+	if err != nil {
+		return err
+	}
+	defer dt.Close()
+
+	if tag.pack {
+		if dt.Class() != T_COMPOUND {
+			return fmt.Errorf("hdf5: can not pack non-struct field %s", f.Name)
+		}
+		if err := h5err(C.H5Tpack(dt.id)); err != nil {
+			return err
+		}
+	}
+	if err := cdt.Insert(tag.name, int(f.Offset), dt); err != nil {
+		return fmt.Errorf("hdf5: could not insert field %s: %w", f.Name, err)
+	}
+	return nil
+}
+
+// fieldFileType returns the datatype spec of the struct field f in the file,
+// checking it can be converted from the memory datatype of f.
+func fieldFileType(f reflect.StructField, spec string) (*Datatype, error) {
+	dt, err := parseTypeSpec(spec)
+	if err != nil {
+		return nil, fmt.Errorf("hdf5: invalid type for field %s: %w", f.Name, err)
+	}
+	mt, err := NewDataTypeFromType(f.Type)
+	if err != nil {
+		dt.Close()
+		return nil, err
+	}
+	defer mt.Close()
+	if fc, mc := dt.Class(), mt.Class(); fc != mc {
+		dt.Close()
+		return nil, fmt.Errorf("hdf5: type %q of class %v does not match field %s of class %v", spec, fc, f.Name, mc)
+	}
+	return dt, nil
+}
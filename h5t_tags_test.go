@@ -0,0 +1,261 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hdf5
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestParseFieldTag(t *testing.T) {
+	type fields struct {
+		Plain   int
+		Named   int `hdf5:"name"`
+		Empty   int `hdf5:",pack"`
+		Skipped int `hdf5:"-"`
+		Typed   int `hdf5:"typed,type=i32be"`
+		JSON    int `json:"json"`
+		Both    int `json:"both" hdf5:"both"`
+		Invalid int `hdf5:"invalid,omitempty"`
+		NoType  int `hdf5:"notype,type="`
+	}
+	for _, test := range []struct {
+		field string
+		want  fieldTag
+		err   bool
+	}{
+		{field: "Plain", want: fieldTag{name: "Plain"}},
+		{field: "Named", want: fieldTag{name: "name"}},
+		{field: "Empty", want: fieldTag{name: "Empty", pack: true}},
+		{field: "Skipped", want: fieldTag{name: "Skipped", skip: true}},
+		{field: "Typed", want: fieldTag{name: "typed", ftype: "i32be"}},
+		{field: "JSON", want: fieldTag{name: "JSON"}},
+		{field: "Both", want: fieldTag{name: "both"}},
+		{field: "Invalid", err: true},
+		{field: "NoType", err: true},
+	} {
+		f, ok := reflect.TypeOf(fields{}).FieldByName(test.field)
+		if !ok {
+			t.Fatalf("missing field %s", test.field)
+		}
+		got, err := parseFieldTag(f)
+		if test.err {
+			if err == nil {
+				t.Errorf("%s: expected an error", test.field)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", test.field, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("%s: got %+v, want %+v", test.field, got, test.want)
+		}
+	}
+
+	// raw tags, which can not be declared without upsetting go vet.
+	legacy := reflect.StructField{Name: "Legacy", Type: reflect.TypeOf(0), Tag: "Legacy Name"}
+	got, err := parseFieldTag(legacy)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := (fieldTag{name: "Legacy Name"}); got != want {
+		t.Errorf("raw tag: got %+v, want %+v", got, want)
+	}
+}
+
+func TestStructTags(t *testing.T) {
+	type inner struct {
+		A int8
+		B int64
+	}
+	type record struct {
+		ID     int32   `json:"id" hdf5:"id"`
+		Temp   float64 `hdf5:"temperature,type=f32le"`
+		Cache  int64   `hdf5:"-"`
+		Count  uint16  `hdf5:",type=H5T_STD_U16BE"`
+		Nested inner   `hdf5:"nested,pack"`
+	}
+
+	mtype, err := NewDataTypeFromType(reflect.TypeOf(record{}))
+	if err != nil {
+		t.Fatalf("NewDataTypeFromType failed: %s", err)
+	}
+	defer mtype.Close()
+	ftype, err := NewFileDataTypeFromType(reflect.TypeOf(record{}))
+	if err != nil {
+		t.Fatalf("NewFileDataTypeFromType failed: %s", err)
+	}
+	defer ftype.Close()
+
+	names := []string{"id", "temperature", "Count", "nested"}
+	for _, dt := range []*Datatype{mtype, ftype} {
+		cdt := CompoundType{*dt}
+		var got []string
+		for i := 0; i < cdt.NMembers(); i++ {
+			got = append(got, cdt.MemberName(i))
+		}
+		if !reflect.DeepEqual(got, names) {
+			t.Errorf("invalid member names: got %q, want %q", got, names)
+		}
+	}
+
+	fcdt := CompoundType{*ftype}
+	for _, test := range []struct {
+		member string
+		want   *Datatype
+	}{
+		{"id", T_NATIVE_INT32},
+		{"temperature", T_IEEE_F32LE},
+		{"Count", T_STD_U16BE},
+	} {
+		mt, err := fcdt.MemberType(fcdt.MemberIndex(test.member))
+		if err != nil {
+			t.Fatalf("MemberType(%q) failed: %s", test.member, err)
+		}
+		if !mt.Equal(test.want) {
+			t.Errorf("invalid file datatype for member %q", test.member)
+		}
+		mt.Close()
+	}
+	nested, err := fcdt.MemberType(fcdt.MemberIndex("nested"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := nested.Size(), uint(9); got != want {
+		t.Errorf("invalid size of packed member: got %d, want %d", got, want)
+	}
+	nested.Close()
+
+	type badType struct {
+		X float64 `hdf5:"x,type=i32"`
+	}
+	if _, err := NewFileDataTypeFromType(reflect.TypeOf(badType{})); err == nil {
+		t.Errorf("expected an error on mismatched type class")
+	}
+	type badPack struct {
+		X float64 `hdf5:"x,pack"`
+	}
+	if _, err := NewFileDataTypeFromType(reflect.TypeOf(badPack{})); err == nil {
+		t.Errorf("expected an error packing a non-struct field")
+	}
+
+	// round trip through a dataset stored with the file datatype.
+	f, err := CreateFile(fname, F_ACC_TRUNC)
+	if err != nil {
+		t.Fatalf("CreateFile failed: %s", err)
+	}
+	defer os.Remove(fname)
+	defer f.Close()
+
+	data := []record{
+		{ID: 1, Temp: 20.5, Cache: 10, Count: 3, Nested: inner{1, 2}},
+		{ID: 2, Temp: -4.25, Cache: 20, Count: 4, Nested: inner{3, 4}},
+	}
+	space, err := CreateSimpleDataspace([]uint{uint(len(data))}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer space.Close()
+	dset, err := f.CreateDataset("records", ftype, space)
+	if err != nil {
+		t.Fatalf("CreateDataset failed: %s", err)
+	}
+	defer dset.Close()
+	if err := Write(dset, data); err != nil {
+		t.Fatalf("Write failed: %s", err)
+	}
+	got, _, err := ReadAll[record](dset)
+	if err != nil {
+		t.Fatalf("ReadAll failed: %s", err)
+	}
+	for i := range data {
+		data[i].Cache = 0 // skipped fields are not stored.
+	}
+	if !reflect.DeepEqual(got, data) {
+		t.Errorf("invalid records:\ngot= %+v\nwant=%+v", got, data)
+	}
+}
+
+type packedRecord struct {
+	A int8
+	B float64
+	C uint16
+	D float32 `hdf5:"d,type=f64le"`
+}
+
+func TestPackedDataType(t *testing.T) {
+	rt := reflect.TypeOf(packedRecord{})
+	if _, err := NewPackedDataTypeFromType(rt, T_ORDER_NONE); err == nil {
+		t.Errorf("expected an error for an invalid byte order")
+	}
+
+	for _, test := range []struct {
+		name  string
+		order ByteOrder
+		types []*Datatype
+	}{
+		{"LE", T_ORDER_LE, []*Datatype{T_STD_I8LE, T_IEEE_F64LE, T_STD_U16LE, T_IEEE_F64LE}},
+		{"BE", T_ORDER_BE, []*Datatype{T_STD_I8BE, T_IEEE_F64BE, T_STD_U16BE, T_IEEE_F64LE}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			testPackedDataType(t, test.order, test.types)
+		})
+	}
+}
+
+func testPackedDataType(t *testing.T, order ByteOrder, types []*Datatype) {
+	dtype, err := NewPackedDataTypeFromType(reflect.TypeOf(packedRecord{}), order)
+	if err != nil {
+		t.Fatalf("NewPackedDataTypeFromType failed: %s", err)
+	}
+	defer dtype.Close()
+	if got, want := dtype.Size(), uint(1+8+2+8); got != want {
+		t.Errorf("invalid size of packed datatype: got %d, want %d", got, want)
+	}
+	cdt := CompoundType{*dtype}
+	for i, want := range types {
+		mt, err := cdt.MemberType(i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !mt.Equal(want) {
+			t.Errorf("invalid datatype for member %q", cdt.MemberName(i))
+		}
+		mt.Close()
+	}
+
+	// values are converted between the packed and the native layouts.
+	f, err := CreateFile(fname, F_ACC_TRUNC)
+	if err != nil {
+		t.Fatalf("CreateFile failed: %s", err)
+	}
+	defer os.Remove(fname)
+	defer f.Close()
+
+	data := []packedRecord{{1, 1.5, 2, 2.5}, {-3, -3.5, 4, 4.5}}
+	space, err := CreateSimpleDataspace([]uint{uint(len(data))}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer space.Close()
+	dset, err := f.CreateDataset("records", dtype, space)
+	if err != nil {
+		t.Fatalf("CreateDataset failed: %s", err)
+	}
+	defer dset.Close()
+	if err := dset.Write(&data); err != nil {
+		t.Fatalf("Write failed: %s", err)
+	}
+	got := make([]packedRecord, len(data))
+	if err := dset.Read(&got); err != nil {
+		t.Fatalf("Read failed: %s", err)
+	}
+	if !reflect.DeepEqual(got, data) {
+		t.Errorf("invalid records: got %v, want %v", got, data)
+	}
+}
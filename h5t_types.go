@@ -7,6 +7,89 @@ package hdf5
 // #include "hdf5.h"
 // #include <stdlib.h>
 // #include <string.h>
+//
+// // _go_hdf5_enum_buf returns a buffer large enough to hold a value of the
+// // base type of the enumeration type, or a long long.
+// static void *_go_hdf5_enum_buf(hid_t type) {
+//   size_t sz = H5Tget_size(type);
+//   if (sz < sizeof(long long)) {
+//     sz = sizeof(long long);
+//   }
+//   return calloc(1, sz);
+// }
+//
+// // _go_hdf5_enum_insert returns 1 without inserting the member when value
+// // is out of the range of the base type of the enumeration type.
+// static herr_t _go_hdf5_enum_insert(hid_t type, const char *name, long long value) {
+//   herr_t rc = -1;
+//   long long back;
+//   hid_t base = H5Tget_super(type);
+//   void *buf = _go_hdf5_enum_buf(type);
+//   void *tmp = _go_hdf5_enum_buf(type);
+//   if (base < 0 || buf == NULL || tmp == NULL) {
+//     goto done;
+//   }
+//   memcpy(buf, &value, sizeof(value));
+//   if (H5Tconvert(H5T_NATIVE_LLONG, base, 1, buf, NULL, H5P_DEFAULT) < 0) {
+//     goto done;
+//   }
+//   memcpy(tmp, buf, H5Tget_size(base));
+//   if (H5Tconvert(base, H5T_NATIVE_LLONG, 1, tmp, NULL, H5P_DEFAULT) < 0) {
+//     goto done;
+//   }
+//   memcpy(&back, tmp, sizeof(back));
+//   if (back != value) {
+//     rc = 1;
+//     goto done;
+//   }
+//   rc = H5Tenum_insert(type, name, buf);
+// done:
+//   free(buf);
+//   free(tmp);
+//   if (base >= 0) {
+//     H5Tclose(base);
+//   }
+//   return rc;
+// }
+//
+// static herr_t _go_hdf5_enum_to_llong(hid_t type, void *buf, long long *value) {
+//   hid_t base = H5Tget_super(type);
+//   if (base < 0) {
+//     return -1;
+//   }
+//   herr_t rc = H5Tconvert(base, H5T_NATIVE_LLONG, 1, buf, NULL, H5P_DEFAULT);
+//   H5Tclose(base);
+//   if (rc >= 0) {
+//     memcpy(value, buf, sizeof(*value));
+//   }
+//   return rc;
+// }
+//
+// static herr_t _go_hdf5_enum_member_value(hid_t type, unsigned idx, long long *value) {
+//   void *buf = _go_hdf5_enum_buf(type);
+//   if (buf == NULL) {
+//     return -1;
+//   }
+//   herr_t rc = H5Tget_member_value(type, idx, buf);
+//   if (rc >= 0) {
+//     rc = _go_hdf5_enum_to_llong(type, buf, value);
+//   }
+//   free(buf);
+//   return rc;
+// }
+//
+// static herr_t _go_hdf5_enum_valueof(hid_t type, const char *name, long long *value) {
+//   void *buf = _go_hdf5_enum_buf(type);
+//   if (buf == NULL) {
+//     return -1;
+//   }
+//   herr_t rc = H5Tenum_valueof(type, name, buf);
+//   if (rc >= 0) {
+//     rc = _go_hdf5_enum_to_llong(type, buf, value);
+//   }
+//   free(buf);
+//   return rc;
+// }
 import "C"
 
 import (
@@ -98,6 +181,9 @@ var (
 // OpenDatatype opens a named datatype. The returned datastype must
 // be closed by the user when it is no longer needed.
 func OpenDatatype(c CommonFG, name string, tapl_id int) (*Datatype, error) {
+	h5lock()
+	defer h5unlock()
+
 	c_name := C.CString(name)
 	defer C.free(unsafe.Pointer(c_name))
 
@@ -108,6 +194,18 @@ func OpenDatatype(c CommonFG, name string, tapl_id int) (*Datatype, error) {
 	return NewDatatype(id), nil
 }
 
+// CommitDatatype saves a transient datatype in the file under name, making
+// it a named datatype. Attributes can only be attached to named datatypes.
+func (g *CommonFG) CommitDatatype(name string, dtype *Datatype) error {
+	h5lock()
+	defer h5unlock()
+
+	c_name := C.CString(name)
+	defer C.free(unsafe.Pointer(c_name))
+
+	return h5err(C.H5Tcommit2(g.id, c_name, dtype.id, P_DEFAULT.id, P_DEFAULT.id, P_DEFAULT.id))
+}
+
 // NewDatatype creates a Datatype from an hdf5 id.
 func NewDatatype(id C.hid_t) *Datatype {
 	return &Datatype{Identifier: Identifier{id}}
@@ -117,6 +215,9 @@ func NewDatatype(id C.hid_t) *Datatype {
 // T_OPAQUE, T_ENUM or T_STRING, and size is the size of the new datatype in bytes.
 // The returned datatype must be closed by the user when it is no longer needed.
 func CreateDatatype(class TypeClass, size int) (*Datatype, error) {
+	h5lock()
+	defer h5unlock()
+
 	_, ok := parametricTypes[class]
 	if !ok {
 		return nil,
@@ -141,6 +242,9 @@ func (t *Datatype) GoType() reflect.Type {
 
 // Close releases a datatype.
 func (t *Datatype) Close() error {
+	h5lock()
+	defer h5unlock()
+
 	return t.closeWith(h5tclose)
 }
 
@@ -150,11 +254,17 @@ func h5tclose(id C.hid_t) C.herr_t {
 
 // Committed determines whether a datatype is a named type or a transient type.
 func (t *Datatype) Committed() bool {
+	h5lock()
+	defer h5unlock()
+
 	return C.H5Tcommitted(t.id) > 0
 }
 
 // Copy copies an existing datatype.
 func (t *Datatype) Copy() (*Datatype, error) {
+	h5lock()
+	defer h5unlock()
+
 	c, err := copyDatatype(t.id)
 	if err != nil {
 		return nil, err
@@ -173,27 +283,216 @@ func copyDatatype(id C.hid_t) (*Datatype, error) {
 	return NewDatatype(hid), nil
 }
 
+// CreateAttribute creates a new attribute attached to a named datatype.
+// The returned attribute must be closed by the user when it is no longer needed.
+func (t *Datatype) CreateAttribute(name string, dtype *Datatype, dspace *Dataspace) (*Attribute, error) {
+	h5lock()
+	defer h5unlock()
+
+	return createAttribute(t.id, name, dtype, dspace, P_DEFAULT)
+}
+
+// CreateAttributeWith creates a new attribute attached to a named datatype
+// with a user-defined PropList. The returned attribute must be closed
+// by the user when it is no longer needed.
+func (t *Datatype) CreateAttributeWith(name string, dtype *Datatype, dspace *Dataspace, acpl *PropList) (*Attribute, error) {
+	h5lock()
+	defer h5unlock()
+
+	return createAttribute(t.id, name, dtype, dspace, acpl)
+}
+
+// OpenAttribute opens an existing attribute of a named datatype. The returned
+// attribute must be closed by the user when it is no longer needed.
+func (t *Datatype) OpenAttribute(name string) (*Attribute, error) {
+	h5lock()
+	defer h5unlock()
+
+	return openAttribute(t.id, name)
+}
+
+// NumAttributes returns the number of attributes attached to a named datatype.
+func (t *Datatype) NumAttributes() (int, error) {
+	h5lock()
+	defer h5unlock()
+
+	return numAttributes(t.id)
+}
+
+// AttributeNames returns the names of the attributes attached to a named datatype.
+func (t *Datatype) AttributeNames() ([]string, error) {
+	h5lock()
+	defer h5unlock()
+
+	return attributeNames(t.id)
+}
+
+// AttributeExists returns whether an attribute with the specified name
+// is attached to a named datatype.
+func (t *Datatype) AttributeExists(name string) bool {
+	h5lock()
+	defer h5unlock()
+
+	return attributeExists(t.id, name)
+}
+
+// DeleteAttribute removes the named attribute from a named datatype.
+func (t *Datatype) DeleteAttribute(name string) error {
+	h5lock()
+	defer h5unlock()
+
+	return deleteAttribute(t.id, name)
+}
+
+// RenameAttribute renames the attribute oldName attached to a named datatype to newName.
+func (t *Datatype) RenameAttribute(oldName, newName string) error {
+	h5lock()
+	defer h5unlock()
+
+	return renameAttribute(t.id, oldName, newName)
+}
+
+// IterateAttributes calls fn for each attribute attached to a named datatype,
+// in increasing name order. The iteration stops at the first error returned by fn.
+func (t *Datatype) IterateAttributes(fn AttributeFunc) error {
+	h5lock()
+	defer h5unlock()
+
+	return iterateAttributes(t.id, fn)
+}
+
 // Equal determines whether two datatype identifiers refer to the same datatype.
 func (t *Datatype) Equal(o *Datatype) bool {
+	h5lock()
+	defer h5unlock()
+
 	return C.H5Tequal(t.id, o.id) > 0
 }
 
 // Lock locks a datatype.
 func (t *Datatype) Lock() error {
+	h5lock()
+	defer h5unlock()
+
 	return h5err(C.H5Tlock(t.id))
 }
 
 // Size returns the size of the Datatype.
 func (t *Datatype) Size() uint {
+	h5lock()
+	defer h5unlock()
+
 	return uint(C.H5Tget_size(t.id))
 }
 
 // SetSize sets the total size of a Datatype.
 func (t *Datatype) SetSize(sz int) error {
+	h5lock()
+	defer h5unlock()
+
 	err := C.H5Tset_size(t.id, C.size_t(sz))
 	return h5err(err)
 }
 
+// CharSet is the character set of a string datatype.
+type CharSet C.H5T_cset_t
+
+const (
+	T_CSET_ASCII CharSet = C.H5T_CSET_ASCII // US ASCII
+	T_CSET_UTF8  CharSet = C.H5T_CSET_UTF8  // UTF-8 Unicode encoding
+)
+
+// StrPad is the padding of a fixed-length string datatype.
+type StrPad C.H5T_str_t
+
+const (
+	T_STR_NULLTERM StrPad = C.H5T_STR_NULLTERM // Null terminated, as in C
+	T_STR_NULLPAD  StrPad = C.H5T_STR_NULLPAD  // Padded with zeros
+	T_STR_SPACEPAD StrPad = C.H5T_STR_SPACEPAD // Padded with spaces, as in Fortran
+)
+
+// T_VARIABLE is the size of variable-length string datatypes.
+var T_VARIABLE = h5t_VARIABLE
+
+// NewStringType creates a new string datatype holding size bytes, or
+// variable-length strings if size is T_VARIABLE. The returned datatype
+// must be closed by the user when it is no longer needed.
+func NewStringType(size int, cset CharSet) (*Datatype, error) {
+	dt, err := T_C_S1.Copy()
+	if err != nil {
+		return nil, err
+	}
+	if err := dt.SetSize(size); err != nil {
+		dt.Close()
+		return nil, err
+	}
+	if err := dt.SetCharSet(cset); err != nil {
+		dt.Close()
+		return nil, err
+	}
+	return dt, nil
+}
+
+// IsVariableStr returns whether the datatype is a variable-length string.
+func (t *Datatype) IsVariableStr() bool {
+	h5lock()
+	defer h5unlock()
+
+	return C.H5Tis_variable_str(t.id) > 0
+}
+
+// CharSet returns the character set of a string datatype.
+func (t *Datatype) CharSet() CharSet {
+	h5lock()
+	defer h5unlock()
+
+	return CharSet(C.H5Tget_cset(t.id))
+}
+
+// SetCharSet sets the character set of a string datatype.
+func (t *Datatype) SetCharSet(cset CharSet) error {
+	h5lock()
+	defer h5unlock()
+
+	return h5err(C.H5Tset_cset(t.id, C.H5T_cset_t(cset)))
+}
+
+// StrPad returns the padding of a fixed-length string datatype.
+func (t *Datatype) StrPad() StrPad {
+	h5lock()
+	defer h5unlock()
+
+	return StrPad(C.H5Tget_strpad(t.id))
+}
+
+// SetStrPad sets the padding of a fixed-length string datatype.
+func (t *Datatype) SetStrPad(pad StrPad) error {
+	h5lock()
+	defer h5unlock()
+
+	return h5err(C.H5Tset_strpad(t.id, C.H5T_str_t(pad)))
+}
+
+// ByteOrder is the byte order of an atomic datatype.
+type ByteOrder C.H5T_order_t
+
+const (
+	T_ORDER_ERROR ByteOrder = C.H5T_ORDER_ERROR // Error
+	T_ORDER_LE    ByteOrder = C.H5T_ORDER_LE    // Little endian
+	T_ORDER_BE    ByteOrder = C.H5T_ORDER_BE    // Big endian
+	T_ORDER_VAX   ByteOrder = C.H5T_ORDER_VAX   // VAX mixed endian
+	T_ORDER_MIXED ByteOrder = C.H5T_ORDER_MIXED // Compound type with mixed member orders
+	T_ORDER_NONE  ByteOrder = C.H5T_ORDER_NONE  // No particular order
+)
+
+// Order returns the byte order of an atomic datatype.
+func (t *Datatype) Order() ByteOrder {
+	h5lock()
+	defer h5unlock()
+
+	return ByteOrder(C.H5Tget_order(t.id))
+}
+
 type ArrayType struct {
 	Datatype
 }
@@ -202,6 +501,9 @@ type ArrayType struct {
 // of the array and dims specify the dimensions of the array. The returned
 // arraytype must be closed by the user when it is no longer needed.
 func NewArrayType(base_type *Datatype, dims []int) (*ArrayType, error) {
+	h5lock()
+	defer h5unlock()
+
 	ndims := C.uint(len(dims))
 	c_dims := (*C.hsize_t)(unsafe.Pointer(&dims[0]))
 
@@ -215,11 +517,17 @@ func NewArrayType(base_type *Datatype, dims []int) (*ArrayType, error) {
 
 // NDims returns the rank of an ArrayType.
 func (t *ArrayType) NDims() int {
+	h5lock()
+	defer h5unlock()
+
 	return int(C.H5Tget_array_ndims(t.id))
 }
 
 // ArrayDims returns the array dimensions.
 func (t *ArrayType) ArrayDims() []int {
+	h5lock()
+	defer h5unlock()
+
 	rank := t.NDims()
 	dims := make([]int, rank)
 	hdims := make([]C.hsize_t, rank)
@@ -243,6 +551,9 @@ type VarLenType struct {
 // of the VarLenType. The returned variable length type must be closed by the user
 // when it is no longer needed.
 func NewVarLenType(base_type *Datatype) (*VarLenType, error) {
+	h5lock()
+	defer h5unlock()
+
 	id := C.H5Tvlen_create(base_type.id)
 	if err := checkID(id); err != nil {
 		return nil, err
@@ -254,6 +565,9 @@ func NewVarLenType(base_type *Datatype) (*VarLenType, error) {
 
 // IsVariableStr determines whether the VarLenType is a string.
 func (vl *VarLenType) IsVariableStr() bool {
+	h5lock()
+	defer h5unlock()
+
 	return C.H5Tis_variable_str(vl.id) > 0
 }
 
@@ -265,6 +579,9 @@ type CompoundType struct {
 // the compound datatype. The returned compound type must be closed by the user
 // when it is no longer needed.
 func NewCompoundType(size int) (*CompoundType, error) {
+	h5lock()
+	defer h5unlock()
+
 	id := C.H5Tcreate(C.H5T_class_t(T_COMPOUND), C.size_t(size))
 	if err := checkID(id); err != nil {
 		return nil, err
@@ -275,21 +592,33 @@ func NewCompoundType(size int) (*CompoundType, error) {
 
 // NMembers returns the number of elements in a compound or enumeration datatype.
 func (t *CompoundType) NMembers() int {
+	h5lock()
+	defer h5unlock()
+
 	return int(C.H5Tget_nmembers(t.id))
 }
 
 // Class returns the TypeClass of the DataType
 func (t *Datatype) Class() TypeClass {
+	h5lock()
+	defer h5unlock()
+
 	return TypeClass(C.H5Tget_class(t.id))
 }
 
 // MemberClass returns datatype class of compound datatype member.
 func (t *CompoundType) MemberClass(mbr_idx int) TypeClass {
+	h5lock()
+	defer h5unlock()
+
 	return TypeClass(C.H5Tget_member_class(t.id, C.uint(mbr_idx)))
 }
 
 // MemberName returns the name of a compound or enumeration datatype member.
 func (t *CompoundType) MemberName(mbr_idx int) string {
+	h5lock()
+	defer h5unlock()
+
 	c_name := C.H5Tget_member_name(t.id, C.uint(mbr_idx))
 	defer C.free(unsafe.Pointer(c_name))
 	return C.GoString(c_name)
@@ -297,6 +626,9 @@ func (t *CompoundType) MemberName(mbr_idx int) string {
 
 // MemberIndex returns the index of a compound or enumeration datatype member.
 func (t *CompoundType) MemberIndex(name string) int {
+	h5lock()
+	defer h5unlock()
+
 	c_name := C.CString(name)
 	defer C.free(unsafe.Pointer(c_name))
 	return int(C.H5Tget_member_index(t.id, c_name))
@@ -304,12 +636,18 @@ func (t *CompoundType) MemberIndex(name string) int {
 
 // MemberOffset returns the offset of a field of a compound datatype.
 func (t *CompoundType) MemberOffset(mbr_idx int) int {
+	h5lock()
+	defer h5unlock()
+
 	return int(C.H5Tget_member_offset(t.id, C.uint(mbr_idx)))
 }
 
 // MemberType returns the datatype of the specified member. The returned
 // datatype must be closed by the user when it is no longer needed.
 func (t *CompoundType) MemberType(mbr_idx int) (*Datatype, error) {
+	h5lock()
+	defer h5unlock()
+
 	hid := C.H5Tget_member_type(t.id, C.uint(mbr_idx))
 	if err := checkID(hid); err != nil {
 		return nil, err
@@ -319,6 +657,9 @@ func (t *CompoundType) MemberType(mbr_idx int) (*Datatype, error) {
 
 // Insert adds a new member to a compound datatype.
 func (t *CompoundType) Insert(name string, offset int, field *Datatype) error {
+	h5lock()
+	defer h5unlock()
+
 	cname := C.CString(name)
 	defer C.free(unsafe.Pointer(cname))
 	return h5err(C.H5Tinsert(t.id, cname, C.size_t(offset), field.id))
@@ -329,15 +670,169 @@ func (t *CompoundType) Insert(name string, offset int, field *Datatype) error {
 // type on the disk. However, using this may require type conversions
 // on more machines, so may be a worse option.
 func (t *CompoundType) Pack() error {
+	h5lock()
+	defer h5unlock()
+
 	return h5err(C.H5Tpack(t.id))
 }
 
+// EnumType is an enumeration datatype, mapping names onto values of an
+// integer base datatype. An enumeration datatype read from a file can be
+// used as EnumType{*dtype}.
+type EnumType struct {
+	Datatype
+}
+
+// Enum is implemented by Go integer types representing enumerations.
+// NewDataTypeFromType maps such types onto HDF5 enumeration datatypes.
+type Enum interface {
+	// EnumMembers returns the members of the enumeration.
+	EnumMembers() []EnumMember
+}
+
+// EnumMember is a member of an enumeration.
+type EnumMember struct {
+	Name  string
+	Value int64
+}
+
+var _go_enum_t = reflect.TypeOf((*Enum)(nil)).Elem()
+
+// NewEnumType creates a new EnumType with the integer base datatype base.
+// The returned enumeration type must be closed by the user when it is no
+// longer needed.
+func NewEnumType(base *Datatype) (*EnumType, error) {
+	h5lock()
+	defer h5unlock()
+
+	id := C.H5Tenum_create(base.id)
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+	return &EnumType{Datatype{Identifier: Identifier{id}}}, nil
+}
+
+// Insert adds a new member to an enumeration datatype. value is converted
+// to the base datatype of the enumeration, and must be in its range.
+func (t *EnumType) Insert(name string, value int64) error {
+	h5lock()
+	defer h5unlock()
+
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	rc := C._go_hdf5_enum_insert(t.id, cname, C.longlong(value))
+	if rc > 0 {
+		return fmt.Errorf("hdf5: value %d of enumeration member %q out of the range of the base datatype", value, name)
+	}
+	return h5err(rc)
+}
+
+// NMembers returns the number of members of an enumeration datatype.
+func (t *EnumType) NMembers() int {
+	h5lock()
+	defer h5unlock()
+
+	return int(C.H5Tget_nmembers(t.id))
+}
+
+// MemberName returns the name of the member of index idx of an enumeration datatype.
+func (t *EnumType) MemberName(idx int) string {
+	h5lock()
+	defer h5unlock()
+
+	c_name := C.H5Tget_member_name(t.id, C.uint(idx))
+	if c_name == nil {
+		return ""
+	}
+	defer C.free(unsafe.Pointer(c_name))
+	return C.GoString(c_name)
+}
+
+// MemberValue returns the value of the member of index idx of an enumeration datatype.
+func (t *EnumType) MemberValue(idx int) (int64, error) {
+	h5lock()
+	defer h5unlock()
+
+	var value C.longlong
+	err := h5err(C._go_hdf5_enum_member_value(t.id, C.uint(idx), &value))
+	return int64(value), err
+}
+
+// ValueOf returns the value of the member name of an enumeration datatype.
+func (t *EnumType) ValueOf(name string) (int64, error) {
+	h5lock()
+	defer h5unlock()
+
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	var value C.longlong
+	err := h5err(C._go_hdf5_enum_valueof(t.id, cname, &value))
+	return int64(value), err
+}
+
+// newEnumTypeFromType creates the enumeration datatype of the Go type t,
+// an integer type implementing Enum.
+func newEnumTypeFromType(t reflect.Type) (*Datatype, error) {
+	var base *Datatype
+	switch t.Kind() {
+	case reflect.Int:
+		base = T_NATIVE_INT32
+		if t.Size() == 8 {
+			base = T_NATIVE_INT64
+		}
+	case reflect.Int8:
+		base = T_NATIVE_INT8
+	case reflect.Int16:
+		base = T_NATIVE_INT16
+	case reflect.Int32:
+		base = T_NATIVE_INT32
+	case reflect.Int64:
+		base = T_NATIVE_INT64
+	case reflect.Uint:
+		base = T_NATIVE_UINT32
+		if t.Size() == 8 {
+			base = T_NATIVE_UINT64
+		}
+	case reflect.Uint8:
+		base = T_NATIVE_UINT8
+	case reflect.Uint16:
+		base = T_NATIVE_UINT16
+	case reflect.Uint32:
+		base = T_NATIVE_UINT32
+	case reflect.Uint64:
+		base = T_NATIVE_UINT64
+	default:
+		return nil, fmt.Errorf("hdf5: enumeration %v must have an integer kind, not %v", t, t.Kind())
+	}
+	return newEnumTypeWithBase(t, base)
+}
+
+// newEnumTypeWithBase creates the enumeration datatype of the Go type t,
+// an integer type implementing Enum, with the integer datatype base.
+func newEnumTypeWithBase(t reflect.Type, base *Datatype) (*Datatype, error) {
+	et, err := NewEnumType(base)
+	if err != nil {
+		return nil, err
+	}
+	members := reflect.New(t).Interface().(Enum).EnumMembers()
+	for _, m := range members {
+		if err := et.Insert(m.Name, m.Value); err != nil {
+			et.Close()
+			return nil, fmt.Errorf("hdf5: could not insert member %q of enumeration %v: %w", m.Name, t, err)
+		}
+	}
+	return &et.Datatype, nil
+}
+
 type OpaqueDatatype struct {
 	Datatype
 }
 
 // SetTag tags an opaque datatype.
 func (t *OpaqueDatatype) SetTag(tag string) error {
+	h5lock()
+	defer h5unlock()
+
 	ctag := C.CString(tag)
 	defer C.free(unsafe.Pointer(ctag))
 	return h5err(C.H5Tset_tag(t.id, ctag))
@@ -345,6 +840,9 @@ func (t *OpaqueDatatype) SetTag(tag string) error {
 
 // Tag returns the tag associated with an opaque datatype.
 func (t *OpaqueDatatype) Tag() string {
+	h5lock()
+	defer h5unlock()
+
 	cname := C.H5Tget_tag(t.id)
 	if cname != nil {
 		defer C.free(unsafe.Pointer(cname))
@@ -361,11 +859,29 @@ func NewDatatypeFromValue(v interface{}) (*Datatype, error) {
 
 // NewDatatypeFromType creates a new Datatype from a reflect.Type. The returned
 // datatype must be closed by the user when it is no longer needed.
+//
+// Struct fields are mapped onto compound members named after the hdf5 struct
+// tag of the field, or the field name if the tag is absent or has an empty
+// name:
+//
+//	Temp float64 `hdf5:"temperature"` // member "temperature"
+//	Skip int     `hdf5:"-"`           // not a member
+//
+// The file options of the tag are ignored: see NewFileDataTypeFromType.
 func NewDataTypeFromType(t reflect.Type) (*Datatype, error) {
 
 	var dt *Datatype = nil
 	var err error
 
+	switch {
+	case t == _go_object_ref_t:
+		return T_STD_REF_OBJ.Copy()
+	case t == _go_region_ref_t:
+		return T_STD_REF_DSETREG.Copy()
+	case reflect.PtrTo(t).Implements(_go_enum_t):
+		return newEnumTypeFromType(t)
+	}
+
 	switch t.Kind() {
 
 	case reflect.Int:
@@ -448,6 +964,14 @@ func NewDataTypeFromType(t reflect.Type) (*Datatype, error) {
 		n := t.NumField()
 		for i := 0; i < n; i++ {
 			f := t.Field(i)
+			var tag fieldTag
+			tag, err = parseFieldTag(f)
+			if err != nil {
+				return nil, err
+			}
+			if tag.skip {
+				continue
+			}
 			var field_dt *Datatype
 			field_dt, err = NewDataTypeFromType(f.Type)
 			if err != nil {
@@ -460,11 +984,7 @@ func NewDataTypeFromType(t reflect.Type) (*Datatype, error) {
 			if field_dt == nil {
 				return nil, fmt.Errorf("pb with field [%d-%s]", i, f.Name)
 			}
-			field_name := string(f.Tag)
-			if len(field_name) == 0 {
-				field_name = f.Name
-			}
-			err = cdt.Insert(field_name, offset, field_dt)
+			err = cdt.Insert(tag.name, offset, field_dt)
 			if err != nil {
 				return nil, fmt.Errorf("pb with field [%d-%s]: %s", i, f.Name, err)
 			}
@@ -477,8 +997,7 @@ func NewDataTypeFromType(t reflect.Type) (*Datatype, error) {
 		dt.goPtrPathLen++
 
 	default:
-		// Should never happen.
-		panic(fmt.Errorf("unhandled kind (%v)", t.Kind()))
+		return nil, fmt.Errorf("hdf5: unhandled kind (%v)", t.Kind())
 	}
 
 	return dt, err
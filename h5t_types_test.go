@@ -4,7 +4,12 @@
 
 package hdf5
 
-import "testing"
+import (
+	"os"
+	"reflect"
+	"testing"
+	"unsafe"
+)
 
 func TestSimpleDatatypes(t *testing.T) {
 	// Smoke tests for the simple datatypes
@@ -187,3 +192,153 @@ func TestCloseBehavior(t *testing.T) {
 	}
 	defer dtype.Close()
 }
+
+type testState uint8
+
+const (
+	stateIdle testState = iota
+	stateRunning
+	stateDone = 10
+)
+
+func (testState) EnumMembers() []EnumMember {
+	return []EnumMember{
+		{"IDLE", int64(stateIdle)},
+		{"RUNNING", int64(stateRunning)},
+		{"DONE", stateDone},
+	}
+}
+
+func TestEnumDatatype(t *testing.T) {
+	et, err := NewEnumType(T_NATIVE_INT16)
+	if err != nil {
+		t.Fatalf("NewEnumType failed: %s", err)
+	}
+	defer et.Close()
+	for _, m := range []EnumMember{{"A", -1}, {"B", 0}, {"C", 300}} {
+		if err := et.Insert(m.Name, m.Value); err != nil {
+			t.Fatalf("Insert(%q) failed: %s", m.Name, err)
+		}
+	}
+	if err := et.Insert("A", 2); err == nil {
+		t.Fatalf("expected an error inserting a duplicate member")
+	}
+	if err := et.Insert("D", 1<<20); err == nil {
+		t.Fatalf("expected an error inserting an out of range value")
+	}
+	if n := et.NMembers(); n != 3 {
+		t.Fatalf("NMembers: got %d, want 3", n)
+	}
+	if name := et.MemberName(2); name != "C" {
+		t.Fatalf("MemberName(2): got %q, want %q", name, "C")
+	}
+	if v, err := et.MemberValue(0); err != nil || v != -1 {
+		t.Fatalf("MemberValue(0): got (%d, %v), want (-1, nil)", v, err)
+	}
+	if v, err := et.ValueOf("C"); err != nil || v != 300 {
+		t.Fatalf("ValueOf(C): got (%d, %v), want (300, nil)", v, err)
+	}
+	if _, err := et.ValueOf("Z"); err == nil {
+		t.Fatalf("expected an error for an unknown member")
+	}
+
+	dt, err := NewDatatypeFromValue(stateRunning)
+	if err != nil {
+		t.Fatalf("NewDatatypeFromValue failed: %s", err)
+	}
+	defer dt.Close()
+	if dt.Class() != T_ENUM {
+		t.Fatalf("invalid class: got %d, want %d", dt.Class(), T_ENUM)
+	}
+	if dt.Size() != 1 {
+		t.Fatalf("invalid size: got %d, want 1", dt.Size())
+	}
+	st := EnumType{*dt}
+	members := testState(0).EnumMembers()
+	if n := st.NMembers(); n != len(members) {
+		t.Fatalf("NMembers: got %d, want %d", n, len(members))
+	}
+	for i, m := range members {
+		if name := st.MemberName(i); name != m.Name {
+			t.Fatalf("MemberName(%d): got %q, want %q", i, name, m.Name)
+		}
+		if v, err := st.MemberValue(i); err != nil || v != m.Value {
+			t.Fatalf("MemberValue(%d): got (%d, %v), want (%d, nil)", i, v, err, m.Value)
+		}
+	}
+
+	f, err := CreateFile(fname, F_ACC_TRUNC)
+	if err != nil {
+		t.Fatalf("CreateFile failed: %s", err)
+	}
+	defer os.Remove(fname)
+	defer f.Close()
+
+	data := []testState{stateIdle, stateDone, stateRunning}
+	dspace, err := CreateSimpleDataspace([]uint{uint(len(data))}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dspace.Close()
+	dset, err := f.CreateDataset("states", dt, dspace)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dset.Close()
+	if err := Write(dset, data); err != nil {
+		t.Fatalf("Write failed: %s", err)
+	}
+	got, _, err := ReadAll[testState](dset)
+	if err != nil {
+		t.Fatalf("ReadAll failed: %s", err)
+	}
+	if !reflect.DeepEqual(got, data) {
+		t.Fatalf("invalid data: got=%v, want=%v", got, data)
+	}
+}
+
+type testLevel int
+
+func (testLevel) EnumMembers() []EnumMember {
+	return []EnumMember{{"LOW", -1}, {"MID", 2}, {"HIGH", 1000}}
+}
+
+func TestEnumIntDatatype(t *testing.T) {
+	dt, err := NewDatatypeFromValue(testLevel(0))
+	if err != nil {
+		t.Fatalf("NewDatatypeFromValue failed: %s", err)
+	}
+	defer dt.Close()
+	if want := uint(unsafe.Sizeof(testLevel(0))); dt.Size() != want {
+		t.Fatalf("invalid size: got %d, want %d", dt.Size(), want)
+	}
+
+	f, err := CreateFile(fname, F_ACC_TRUNC)
+	if err != nil {
+		t.Fatalf("CreateFile failed: %s", err)
+	}
+	defer os.Remove(fname)
+	defer f.Close()
+
+	data := []testLevel{2, -1, 1000}
+	dspace, err := CreateSimpleDataspace([]uint{uint(len(data))}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dspace.Close()
+	dset, err := f.CreateDataset("levels", dt, dspace)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dset.Close()
+	if err := dset.Write(&data); err != nil {
+		t.Fatalf("Write failed: %s", err)
+	}
+	got := make([]testLevel, len(data))
+	if err := dset.Read(&got); err != nil {
+		t.Fatalf("Read failed: %s", err)
+	}
+	if !reflect.DeepEqual(got, data) {
+		t.Fatalf("invalid data: got=%v, want=%v", got, data)
+	}
+}
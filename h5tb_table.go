@@ -0,0 +1,403 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hdf5
+
+// #include "hdf5.h"
+// #include "hdf5_hl.h"
+// #include <stdlib.h>
+import "C"
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unsafe"
+)
+
+// RecordTable is an hdf5 table, as managed by the HDF5 table high-level API.
+// Unlike a packet table, the records of a RecordTable can be updated,
+// inserted and deleted, and its fields read individually.
+//
+// Records are exchanged as slices or arrays of Go structs, whose fields must
+// match the fields of the table, in order.
+type RecordTable struct {
+	loc  Identifier // location of the table, kept open by the table.
+	name string
+}
+
+// TableField describes a field of a RecordTable.
+type TableField struct {
+	Name   string // Name of the field
+	Offset uint   // Offset of the field in a record, in bytes
+	Size   uint   // Size of the field, in bytes
+}
+
+// TableInfo describes the layout and the content of a RecordTable.
+type TableInfo struct {
+	Fields     []TableField // Fields of the table, in order
+	RecordSize uint         // Size of a record, in bytes
+	NumRecords int          // Number of records of the table
+}
+
+func newRecordTable(loc C.hid_t, name string) (*RecordTable, error) {
+	if C.H5Iinc_ref(loc) < 0 {
+		return nil, fmt.Errorf("hdf5: could not reference location of table %q", name)
+	}
+	return &RecordTable{loc: Identifier{loc}, name: name}, nil
+}
+
+// MakeTable creates a table with the fields of record, a Go struct value or
+// its reflect.Type. Fields are named and stored following their hdf5 struct
+// tags, as described by NewFileDataTypeFromType, but must keep their size
+// in the file. chunkSize is the number of records of a storage chunk and
+// compress enables the compression of the table.
+// The returned table must be closed by the user when it is no longer needed.
+func (g *CommonFG) MakeTable(name, title string, record interface{}, chunkSize int, compress bool) (*RecordTable, error) {
+	h5lock()
+	defer h5unlock()
+
+	rt, ok := record.(reflect.Type)
+	if !ok {
+		rt = reflect.TypeOf(record)
+	}
+	layout, err := newRecordLayout(rt)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fields are described by the members of the file datatype of records.
+	dtype, err := NewFileDataTypeFromType(rt)
+	if err != nil {
+		return nil, err
+	}
+	defer dtype.Close()
+	cdt := CompoundType{*dtype}
+	n := cdt.NMembers()
+	var (
+		names = make([]*C.char, n)
+		types = make([]C.hid_t, n)
+	)
+	for i := 0; i < n; i++ {
+		names[i] = C.CString(cdt.MemberName(i))
+		defer C.free(unsafe.Pointer(names[i]))
+		ftype, err := cdt.MemberType(i)
+		if err != nil {
+			return nil, err
+		}
+		defer ftype.Close()
+		types[i] = ftype.id
+	}
+
+	c_name := C.CString(name)
+	defer C.free(unsafe.Pointer(c_name))
+	c_title := C.CString(title)
+	defer C.free(unsafe.Pointer(c_title))
+	var c_compress C.int
+	if compress {
+		c_compress = 1
+	}
+	err = h5err(C.H5TBmake_table(c_title, g.id, c_name, C.hsize_t(n), 0, layout.size,
+		&names[0], &layout.offsets[0], &types[0], C.hsize_t(chunkSize), nil, c_compress, nil))
+	if err != nil {
+		return nil, err
+	}
+	return newRecordTable(g.id, name)
+}
+
+// OpenRecordTable opens the existing table name.
+// The returned table must be closed by the user when it is no longer needed.
+func (g *CommonFG) OpenRecordTable(name string) (*RecordTable, error) {
+	h5lock()
+	defer h5unlock()
+
+	c_name := C.CString(name)
+	defer C.free(unsafe.Pointer(c_name))
+
+	var nfields, nrecords C.hsize_t
+	if err := h5err(C.H5TBget_table_info(g.id, c_name, &nfields, &nrecords)); err != nil {
+		return nil, fmt.Errorf("hdf5: could not open table %q: %w", name, err)
+	}
+	return newRecordTable(g.id, name)
+}
+
+// Close releases the table.
+func (t *RecordTable) Close() error {
+	h5lock()
+	defer h5unlock()
+
+	return t.loc.closeWith(h5idecref)
+}
+
+func h5idecref(id C.hid_t) C.herr_t {
+	if C.H5Idec_ref(id) < 0 {
+		return -1
+	}
+	return 0
+}
+
+// Name returns the name of the table, relative to its location.
+func (t *RecordTable) Name() string {
+	return t.name
+}
+
+// NumRecords returns the number of records of the table.
+func (t *RecordTable) NumRecords() (int, error) {
+	h5lock()
+	defer h5unlock()
+
+	c_name := C.CString(t.name)
+	defer C.free(unsafe.Pointer(c_name))
+
+	var nfields, nrecords C.hsize_t
+	err := h5err(C.H5TBget_table_info(t.loc.id, c_name, &nfields, &nrecords))
+	return int(nrecords), err
+}
+
+// Info returns the description of the fields and the number of records
+// of the table.
+func (t *RecordTable) Info() (TableInfo, error) {
+	h5lock()
+	defer h5unlock()
+
+	c_name := C.CString(t.name)
+	defer C.free(unsafe.Pointer(c_name))
+
+	var nfields, nrecords C.hsize_t
+	if err := h5err(C.H5TBget_table_info(t.loc.id, c_name, &nfields, &nrecords)); err != nil {
+		return TableInfo{}, err
+	}
+	n := int(nfields)
+	if n == 0 {
+		return TableInfo{NumRecords: int(nrecords)}, nil
+	}
+
+	var (
+		names   = make([]*C.char, n)
+		sizes   = make([]C.size_t, n)
+		offsets = make([]C.size_t, n)
+		size    C.size_t
+	)
+	for i := range names {
+		names[i] = (*C.char)(C.calloc(C.HLTB_MAX_FIELD_LEN, 1))
+		defer C.free(unsafe.Pointer(names[i]))
+	}
+	if err := h5err(C.H5TBget_field_info(t.loc.id, c_name, &names[0], &sizes[0], &offsets[0], &size)); err != nil {
+		return TableInfo{}, err
+	}
+
+	info := TableInfo{
+		Fields:     make([]TableField, n),
+		RecordSize: uint(size),
+		NumRecords: int(nrecords),
+	}
+	for i := range info.Fields {
+		info.Fields[i] = TableField{
+			Name:   C.GoString(names[i]),
+			Offset: uint(offsets[i]),
+			Size:   uint(sizes[i]),
+		}
+	}
+	return info, nil
+}
+
+// AppendRecords appends records to the end of the table. data must be a
+// slice or an array of structs, or a pointer to one.
+func (t *RecordTable) AppendRecords(data interface{}) error {
+	h5lock()
+	defer h5unlock()
+
+	buf, layout, err := t.records(data, false)
+	if err != nil || buf.n == 0 {
+		return err
+	}
+	c_name := C.CString(t.name)
+	defer C.free(unsafe.Pointer(c_name))
+	return h5err(C.H5TBappend_records(t.loc.id, c_name, C.hsize_t(buf.n), layout.size,
+		&layout.offsets[0], &layout.sizes[0], buf.addr))
+}
+
+// WriteRecords overwrites the records of the table starting at record start
+// with data, a slice or an array of structs, or a pointer to one.
+func (t *RecordTable) WriteRecords(start int, data interface{}) error {
+	h5lock()
+	defer h5unlock()
+
+	buf, layout, err := t.records(data, false)
+	if err != nil || buf.n == 0 {
+		return err
+	}
+	c_name := C.CString(t.name)
+	defer C.free(unsafe.Pointer(c_name))
+	return h5err(C.H5TBwrite_records(t.loc.id, c_name, C.hsize_t(start), C.hsize_t(buf.n), layout.size,
+		&layout.offsets[0], &layout.sizes[0], buf.addr))
+}
+
+// ReadRecords reads records of the table starting at record start into data,
+// a slice of structs or a pointer to an array or a slice of structs.
+// The number of records read is the length of data.
+func (t *RecordTable) ReadRecords(start int, data interface{}) error {
+	h5lock()
+	defer h5unlock()
+
+	buf, layout, err := t.records(data, true)
+	if err != nil || buf.n == 0 {
+		return err
+	}
+	c_name := C.CString(t.name)
+	defer C.free(unsafe.Pointer(c_name))
+	return h5err(C.H5TBread_records(t.loc.id, c_name, C.hsize_t(start), C.hsize_t(buf.n), layout.size,
+		&layout.offsets[0], &layout.sizes[0], buf.addr))
+}
+
+// InsertRecords inserts records into the table before record start. data must
+// be a slice or an array of structs, or a pointer to one.
+func (t *RecordTable) InsertRecords(start int, data interface{}) error {
+	h5lock()
+	defer h5unlock()
+
+	buf, layout, err := t.records(data, false)
+	if err != nil || buf.n == 0 {
+		return err
+	}
+	c_name := C.CString(t.name)
+	defer C.free(unsafe.Pointer(c_name))
+	return h5err(C.H5TBinsert_record(t.loc.id, c_name, C.hsize_t(start), C.hsize_t(buf.n), layout.size,
+		&layout.offsets[0], &layout.sizes[0], buf.addr))
+}
+
+// DeleteRecords deletes n records of the table, starting at record start.
+func (t *RecordTable) DeleteRecords(start, n int) error {
+	h5lock()
+	defer h5unlock()
+
+	c_name := C.CString(t.name)
+	defer C.free(unsafe.Pointer(c_name))
+	return h5err(C.H5TBdelete_record(t.loc.id, c_name, C.hsize_t(start), C.hsize_t(n)))
+}
+
+// ReadFieldsByName reads the fields names of the records of the table starting
+// at record start into data, a slice of structs or a pointer to an array or a
+// slice of structs. The fields of the structs must match the named fields, in
+// order. The number of records read is the length of data.
+func (t *RecordTable) ReadFieldsByName(names []string, start int, data interface{}) error {
+	h5lock()
+	defer h5unlock()
+
+	buf, err := newLiteBuffer(data, true)
+	if err != nil {
+		return err
+	}
+	layout, err := newRecordLayout(buf.elem)
+	if err != nil {
+		return err
+	}
+	if len(names) != len(layout.offsets) {
+		return fmt.Errorf("hdf5: number of fields mismatch (got %d names for %v)", len(names), buf.elem)
+	}
+	if buf.n == 0 {
+		return nil
+	}
+
+	c_name := C.CString(t.name)
+	defer C.free(unsafe.Pointer(c_name))
+	c_fields := C.CString(strings.Join(names, ","))
+	defer C.free(unsafe.Pointer(c_fields))
+	return h5err(C.H5TBread_fields_name(t.loc.id, c_name, c_fields, C.hsize_t(start), C.hsize_t(buf.n), layout.size,
+		&layout.offsets[0], &layout.sizes[0], buf.addr))
+}
+
+// AddField inserts the field name with datatype dtype at the index position of
+// the fields of the table. The field of the existing records is set to fill,
+// a pointer to a value of a Go type matching dtype, if it is not nil.
+func (t *RecordTable) AddField(name string, dtype *Datatype, position int, fill interface{}) error {
+	h5lock()
+	defer h5unlock()
+
+	var addr unsafe.Pointer
+	if fill != nil {
+		var err error
+		addr, err = fillValueAddr(fill)
+		if err != nil {
+			return err
+		}
+	}
+	c_name := C.CString(t.name)
+	defer C.free(unsafe.Pointer(c_name))
+	c_field := C.CString(name)
+	defer C.free(unsafe.Pointer(c_field))
+	return h5err(C.H5TBinsert_field(t.loc.id, c_name, c_field, dtype.id, C.hsize_t(position), addr, nil))
+}
+
+// DeleteField deletes the field name from the table.
+func (t *RecordTable) DeleteField(name string) error {
+	h5lock()
+	defer h5unlock()
+
+	c_name := C.CString(t.name)
+	defer C.free(unsafe.Pointer(c_name))
+	c_field := C.CString(name)
+	defer C.free(unsafe.Pointer(c_field))
+	return h5err(C.H5TBdelete_field(t.loc.id, c_name, c_field))
+}
+
+// records returns the description of the records held by data and checks
+// their layout against the fields of the table.
+func (t *RecordTable) records(data interface{}, writable bool) (liteBuffer, recordLayout, error) {
+	buf, err := newLiteBuffer(data, writable)
+	if err != nil {
+		return buf, recordLayout{}, err
+	}
+	layout, err := newRecordLayout(buf.elem)
+	if err != nil {
+		return buf, layout, err
+	}
+	info, err := t.Info()
+	if err != nil {
+		return buf, layout, err
+	}
+	if len(info.Fields) != len(layout.sizes) {
+		return buf, layout, fmt.Errorf("hdf5: number of fields mismatch for %v (got %d, want %d)", buf.elem, len(layout.sizes), len(info.Fields))
+	}
+	for i, f := range info.Fields {
+		if uint(layout.sizes[i]) != f.Size {
+			return buf, layout, fmt.Errorf("hdf5: size mismatch for field %q of %v (got %d, want %d)", f.Name, buf.elem, layout.sizes[i], f.Size)
+		}
+	}
+	return buf, layout, nil
+}
+
+// recordLayout describes the memory layout of the records of a table.
+type recordLayout struct {
+	size    C.size_t   // size of a record
+	offsets []C.size_t // offsets of the fields
+	sizes   []C.size_t // sizes of the fields
+}
+
+// newRecordLayout returns the memory layout of records of the struct type t.
+func newRecordLayout(t reflect.Type) (recordLayout, error) {
+	if t.Kind() != reflect.Struct {
+		return recordLayout{}, fmt.Errorf("hdf5: table records must be structs, not %v", t)
+	}
+	if hasGoPointers(t) {
+		return recordLayout{}, fmt.Errorf("hdf5: table records can not hold Go pointers (%v)", t)
+	}
+	layout := recordLayout{size: C.size_t(t.Size())}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, err := parseFieldTag(f)
+		if err != nil {
+			return layout, err
+		}
+		if tag.skip {
+			continue
+		}
+		layout.offsets = append(layout.offsets, C.size_t(f.Offset))
+		layout.sizes = append(layout.sizes, C.size_t(f.Type.Size()))
+	}
+	if len(layout.offsets) == 0 {
+		return layout, fmt.Errorf("hdf5: table records must have fields (%v)", t)
+	}
+	return layout, nil
+}
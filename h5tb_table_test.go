@@ -0,0 +1,135 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hdf5
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestRecordTable(t *testing.T) {
+	f, err := CreateFile(fname, F_ACC_TRUNC)
+	if err != nil {
+		t.Fatalf("CreateFile failed: %s", err)
+	}
+	defer os.Remove(fname)
+	defer f.Close()
+
+	type particle struct {
+		ID     int32
+		Energy float64
+		Tag    [4]byte
+	}
+
+	table, err := f.MakeTable("particles", "Particles", particle{}, 8, false)
+	if err != nil {
+		t.Fatalf("MakeTable failed: %s", err)
+	}
+	defer table.Close()
+
+	info, err := table.Info()
+	if err != nil {
+		t.Fatalf("Info failed: %s", err)
+	}
+	rt := reflect.TypeOf(particle{})
+	want := TableInfo{RecordSize: uint(rt.Size())}
+	for i := 0; i < rt.NumField(); i++ {
+		fld := rt.Field(i)
+		want.Fields = append(want.Fields, TableField{Name: fld.Name, Offset: uint(fld.Offset), Size: uint(fld.Type.Size())})
+	}
+	if !reflect.DeepEqual(info, want) {
+		t.Errorf("invalid table info:\ngot= %+v\nwant=%+v", info, want)
+	}
+
+	records := []particle{
+		{1, 1.5, [4]byte{'a'}},
+		{2, 2.5, [4]byte{'b'}},
+		{3, 3.5, [4]byte{'c'}},
+	}
+	if err := table.AppendRecords(records); err != nil {
+		t.Fatalf("AppendRecords failed: %s", err)
+	}
+	if err := table.AppendRecords([]float64{1}); err == nil {
+		t.Errorf("expected an error appending non-struct records")
+	}
+	if err := table.AppendRecords([]struct{ ID int32 }{{1}}); err == nil {
+		t.Errorf("expected an error appending records with mismatched fields")
+	}
+
+	if err := table.WriteRecords(1, []particle{{20, 20.5, [4]byte{'B'}}}); err != nil {
+		t.Fatalf("WriteRecords failed: %s", err)
+	}
+	if err := table.InsertRecords(0, []particle{{0, 0.5, [4]byte{'z'}}}); err != nil {
+		t.Fatalf("InsertRecords failed: %s", err)
+	}
+	if err := table.DeleteRecords(3, 1); err != nil {
+		t.Fatalf("DeleteRecords failed: %s", err)
+	}
+
+	n, err := table.NumRecords()
+	if err != nil {
+		t.Fatalf("NumRecords failed: %s", err)
+	}
+	if n != 3 {
+		t.Fatalf("invalid number of records: got %d, want 3", n)
+	}
+	got := make([]particle, n)
+	if err := table.ReadRecords(0, got); err != nil {
+		t.Fatalf("ReadRecords failed: %s", err)
+	}
+	wantRecords := []particle{
+		{0, 0.5, [4]byte{'z'}},
+		{1, 1.5, [4]byte{'a'}},
+		{20, 20.5, [4]byte{'B'}},
+	}
+	if !reflect.DeepEqual(got, wantRecords) {
+		t.Errorf("invalid records:\ngot= %v\nwant=%v", got, wantRecords)
+	}
+
+	type energyID struct {
+		Energy float64
+		ID     int32
+	}
+	fields := make([]energyID, 2)
+	if err := table.ReadFieldsByName([]string{"Energy", "ID"}, 1, fields); err != nil {
+		t.Fatalf("ReadFieldsByName failed: %s", err)
+	}
+	if want := []energyID{{1.5, 1}, {20.5, 20}}; !reflect.DeepEqual(fields, want) {
+		t.Errorf("invalid fields: got %v, want %v", fields, want)
+	}
+
+	// fields.
+	flag := int16(7)
+	if err := table.AddField("Flag", T_NATIVE_INT16, 1, &flag); err != nil {
+		t.Fatalf("AddField failed: %s", err)
+	}
+	if err := table.DeleteField("Tag"); err != nil {
+		t.Fatalf("DeleteField failed: %s", err)
+	}
+	table.Close()
+
+	table, err = f.OpenRecordTable("particles")
+	if err != nil {
+		t.Fatalf("OpenRecordTable failed: %s", err)
+	}
+	defer table.Close()
+	type flagged struct {
+		ID     int32
+		Flag   int16
+		Energy float64
+	}
+	got2 := make([]flagged, 3)
+	if err := table.ReadRecords(0, got2); err != nil {
+		t.Fatalf("ReadRecords failed: %s", err)
+	}
+	if want := []flagged{{0, 7, 0.5}, {1, 7, 1.5}, {20, 7, 20.5}}; !reflect.DeepEqual(got2, want) {
+		t.Errorf("invalid records after field changes: got %v, want %v", got2, want)
+	}
+
+	if _, err := f.OpenRecordTable("missing"); err == nil {
+		t.Errorf("expected an error opening a missing table")
+	}
+}
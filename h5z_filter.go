@@ -0,0 +1,219 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hdf5
+
+// #include "hdf5.h"
+// #include <stdlib.h>
+import "C"
+
+import (
+	"fmt"
+)
+
+// FilterID is the identifier of a filter in the HDF5 filter pipeline.
+// Identifiers of third-party filters are registered with The HDF Group.
+type FilterID C.H5Z_filter_t
+
+const (
+	Z_FILTER_ALL         FilterID = C.H5Z_FILTER_ALL         // All filters, used with RemoveFilter
+	Z_FILTER_DEFLATE     FilterID = C.H5Z_FILTER_DEFLATE     // Deflate (GNU gzip) compression
+	Z_FILTER_SHUFFLE     FilterID = C.H5Z_FILTER_SHUFFLE     // Shuffle algorithm
+	Z_FILTER_FLETCHER32  FilterID = C.H5Z_FILTER_FLETCHER32  // Fletcher32 checksum
+	Z_FILTER_SZIP        FilterID = C.H5Z_FILTER_SZIP        // Szip compression
+	Z_FILTER_NBIT        FilterID = C.H5Z_FILTER_NBIT        // N-bit compression
+	Z_FILTER_SCALEOFFSET FilterID = C.H5Z_FILTER_SCALEOFFSET // Scale+offset compression
+)
+
+// Filter flags, used with SetFilter.
+const (
+	Z_FLAG_MANDATORY uint = C.H5Z_FLAG_MANDATORY // The filter must not fail
+	Z_FLAG_OPTIONAL  uint = C.H5Z_FLAG_OPTIONAL  // The filter may fail for a chunk, which is then stored unfiltered
+)
+
+// Filter configuration flags, reported by FilterInfo.
+const (
+	Z_FILTER_CONFIG_ENCODE_ENABLED uint = C.H5Z_FILTER_CONFIG_ENCODE_ENABLED // Encoding is enabled
+	Z_FILTER_CONFIG_DECODE_ENABLED uint = C.H5Z_FILTER_CONFIG_DECODE_ENABLED // Decoding is enabled
+)
+
+// ScaleType is the type of scaling of the scale+offset filter.
+type ScaleType C.H5Z_SO_scale_type_t
+
+const (
+	Z_SO_FLOAT_DSCALE ScaleType = C.H5Z_SO_FLOAT_DSCALE // Floating-point data, D-scaling
+	Z_SO_FLOAT_ESCALE ScaleType = C.H5Z_SO_FLOAT_ESCALE // Floating-point data, E-scaling (not yet implemented by HDF5)
+	Z_SO_INT          ScaleType = C.H5Z_SO_INT          // Integer data
+)
+
+// Z_SO_INT_MINBITS_DEFAULT lets the library compute the minimum number
+// of bits of integer data compressed with the scale+offset filter.
+const Z_SO_INT_MINBITS_DEFAULT int = C.H5Z_SO_INT_MINBITS_DEFAULT
+
+// Szip option masks, used with SetSzip.
+const (
+	SZIP_EC_OPTION_MASK uint = C.H5_SZIP_EC_OPTION_MASK // Entropy coding method
+	SZIP_NN_OPTION_MASK uint = C.H5_SZIP_NN_OPTION_MASK // Nearest neighbor coding method
+)
+
+// FilterAvail returns whether the filter identified by id is available
+// to the application.
+func FilterAvail(id FilterID) (bool, error) {
+	h5lock()
+	defer h5unlock()
+
+	rc := C.H5Zfilter_avail(C.H5Z_filter_t(id))
+	if err := h5err(C.herr_t(rc)); err != nil {
+		return false, err
+	}
+	return rc > 0, nil
+}
+
+// FilterInfo describes a filter of a filter pipeline.
+type FilterInfo struct {
+	ID         FilterID // Filter identifier
+	Name       string   // Filter name
+	Flags      uint     // Z_FLAG_MANDATORY or Z_FLAG_OPTIONAL
+	ClientData []uint   // Auxiliary data of the filter
+	Config     uint     // Combination of the Z_FILTER_CONFIG_XXX flags
+}
+
+// SetShuffle adds the shuffle filter to the filter pipeline.
+// Shuffling the bytes of the data elements usually improves the compression
+// ratio of a subsequent compression filter, such as deflate.
+// https://support.hdfgroup.org/HDF5/doc/RM/RM_H5P.html#Property-SetShuffle
+func (p *PropList) SetShuffle() error {
+	h5lock()
+	defer h5unlock()
+
+	return h5err(C.H5Pset_shuffle(C.hid_t(p.id)))
+}
+
+// SetFletcher32 adds the Fletcher32 checksum filter to the filter pipeline.
+// https://support.hdfgroup.org/HDF5/doc/RM/RM_H5P.html#Property-SetFletcher32
+func (p *PropList) SetFletcher32() error {
+	h5lock()
+	defer h5unlock()
+
+	return h5err(C.H5Pset_fletcher32(C.hid_t(p.id)))
+}
+
+// SetNbit adds the N-bit filter to the filter pipeline.
+// https://support.hdfgroup.org/HDF5/doc/RM/RM_H5P.html#Property-SetNbit
+func (p *PropList) SetNbit() error {
+	h5lock()
+	defer h5unlock()
+
+	return h5err(C.H5Pset_nbit(C.hid_t(p.id)))
+}
+
+// SetScaleOffset adds the scale+offset filter to the filter pipeline.
+// For integer data, factor is the minimum number of bits, or
+// Z_SO_INT_MINBITS_DEFAULT. For floating-point data with D-scaling, factor
+// is the number of decimal digits kept after the decimal point.
+// https://support.hdfgroup.org/HDF5/doc/RM/RM_H5P.html#Property-SetScaleoffset
+func (p *PropList) SetScaleOffset(typ ScaleType, factor int) error {
+	h5lock()
+	defer h5unlock()
+
+	return h5err(C.H5Pset_scaleoffset(C.hid_t(p.id), C.H5Z_SO_scale_type_t(typ), C.int(factor)))
+}
+
+// SetSzip adds the Szip compression filter to the filter pipeline.
+// options is one of SZIP_EC_OPTION_MASK or SZIP_NN_OPTION_MASK, and
+// pixelsPerBlock must be even and not greater than 32.
+// Szip encoding is an optional feature of the HDF5 library, SetSzip
+// returns an error when it is not available.
+// https://support.hdfgroup.org/HDF5/doc/RM/RM_H5P.html#Property-SetSzip
+func (p *PropList) SetSzip(options, pixelsPerBlock uint) error {
+	h5lock()
+	defer h5unlock()
+
+	var config C.uint
+	if err := h5err(C.H5Zget_filter_info(C.H5Z_FILTER_SZIP, &config)); err != nil {
+		return fmt.Errorf("hdf5: szip filter not available: %w", err)
+	}
+	if uint(config)&Z_FILTER_CONFIG_ENCODE_ENABLED == 0 {
+		return fmt.Errorf("hdf5: szip encoding not available")
+	}
+	return h5err(C.H5Pset_szip(C.hid_t(p.id), C.uint(options), C.uint(pixelsPerBlock)))
+}
+
+// SetFilter adds the filter identified by id to the filter pipeline,
+// with the auxiliary data cdValues. It is used to enable registered
+// third-party filters, such as LZF, Blosc or Zstd, which must be available
+// to the HDF5 library, e.g. through the HDF5_PLUGIN_PATH environment variable.
+// https://support.hdfgroup.org/HDF5/doc/RM/RM_H5P.html#Property-SetFilter
+func (p *PropList) SetFilter(id FilterID, flags uint, cdValues []uint) error {
+	h5lock()
+	defer h5unlock()
+
+	var c_values *C.uint
+	if len(cdValues) > 0 {
+		values := make([]C.uint, len(cdValues))
+		for i, v := range cdValues {
+			values[i] = C.uint(v)
+		}
+		c_values = &values[0]
+	}
+	return h5err(C.H5Pset_filter(C.hid_t(p.id), C.H5Z_filter_t(id), C.uint(flags), C.size_t(len(cdValues)), c_values))
+}
+
+// NFilters returns the number of filters in the filter pipeline.
+// https://support.hdfgroup.org/HDF5/doc/RM/RM_H5P.html#Property-GetNFilters
+func (p *PropList) NFilters() (int, error) {
+	h5lock()
+	defer h5unlock()
+
+	n := C.H5Pget_nfilters(C.hid_t(p.id))
+	if err := h5err(C.herr_t(n)); err != nil {
+		return 0, err
+	}
+	return int(n), nil
+}
+
+// Filter returns information about the filter at index idx of the
+// filter pipeline, between 0 and NFilters()-1.
+// https://support.hdfgroup.org/HDF5/doc/RM/RM_H5P.html#Property-GetFilter2
+func (p *PropList) Filter(idx int) (FilterInfo, error) {
+	h5lock()
+	defer h5unlock()
+
+	var (
+		flags  C.uint
+		config C.uint
+		n      C.size_t
+		name   [256]C.char
+	)
+	id := C.H5Pget_filter2(C.hid_t(p.id), C.uint(idx), &flags, &n, nil, 0, nil, nil)
+	if id < 0 {
+		return FilterInfo{}, h5err(C.herr_t(id))
+	}
+	values := make([]C.uint, n+1)
+	id = C.H5Pget_filter2(C.hid_t(p.id), C.uint(idx), &flags, &n, &values[0], C.size_t(len(name)), &name[0], &config)
+	if id < 0 {
+		return FilterInfo{}, h5err(C.herr_t(id))
+	}
+	info := FilterInfo{
+		ID:         FilterID(id),
+		Name:       C.GoString(&name[0]),
+		Flags:      uint(flags),
+		ClientData: make([]uint, n),
+		Config:     uint(config),
+	}
+	for i := range info.ClientData {
+		info.ClientData[i] = uint(values[i])
+	}
+	return info, nil
+}
+
+// RemoveFilter removes the filter identified by id from the filter pipeline.
+// Z_FILTER_ALL removes all the filters.
+// https://support.hdfgroup.org/HDF5/doc/RM/RM_H5P.html#Property-RemoveFilter
+func (p *PropList) RemoveFilter(id FilterID) error {
+	h5lock()
+	defer h5unlock()
+
+	return h5err(C.H5Premove_filter(C.hid_t(p.id), C.H5Z_filter_t(id)))
+}
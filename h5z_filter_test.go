@@ -0,0 +1,114 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hdf5
+
+import (
+	"os"
+	"testing"
+)
+
+func TestFilters(t *testing.T) {
+	for _, id := range []FilterID{Z_FILTER_DEFLATE, Z_FILTER_SHUFFLE, Z_FILTER_FLETCHER32} {
+		ok, err := FilterAvail(id)
+		if err != nil {
+			t.Fatalf("FilterAvail(%d) failed: %s", id, err)
+		}
+		if !ok {
+			t.Fatalf("filter %d should be available", id)
+		}
+	}
+
+	dcpl, err := NewPropList(P_DATASET_CREATE)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dcpl.Close()
+	if err := dcpl.SetChunk([]uint{100}); err != nil {
+		t.Fatal(err)
+	}
+	if err := dcpl.SetShuffle(); err != nil {
+		t.Fatalf("SetShuffle failed: %s", err)
+	}
+	if err := dcpl.SetDeflate(DefaultCompression); err != nil {
+		t.Fatalf("SetDeflate failed: %s", err)
+	}
+	if err := dcpl.SetFletcher32(); err != nil {
+		t.Fatalf("SetFletcher32 failed: %s", err)
+	}
+
+	want := []FilterID{Z_FILTER_SHUFFLE, Z_FILTER_DEFLATE, Z_FILTER_FLETCHER32}
+	n, err := dcpl.NFilters()
+	if err != nil {
+		t.Fatalf("NFilters failed: %s", err)
+	}
+	if n != len(want) {
+		t.Fatalf("NFilters: got %d, want %d", n, len(want))
+	}
+	for i, id := range want {
+		info, err := dcpl.Filter(i)
+		if err != nil {
+			t.Fatalf("Filter(%d) failed: %s", i, err)
+		}
+		if info.ID != id {
+			t.Fatalf("Filter(%d): got id %d, want %d", i, info.ID, id)
+		}
+		if info.Name == "" {
+			t.Fatalf("Filter(%d): empty name", i)
+		}
+		if id == Z_FILTER_DEFLATE && (len(info.ClientData) != 1 || info.ClientData[0] != 6) {
+			t.Fatalf("Filter(%d): got client data %v, want [6]", i, info.ClientData)
+		}
+	}
+	if _, err := dcpl.Filter(n); err == nil {
+		t.Fatalf("expected an error for an out of range filter index")
+	}
+
+	f, err := CreateFile(fname, F_ACC_TRUNC)
+	if err != nil {
+		t.Fatalf("CreateFile failed: %s", err)
+	}
+	defer os.Remove(fname)
+	defer f.Close()
+
+	data := make([]float64, 1000)
+	for i := range data {
+		data[i] = float64(i) * 0.5
+	}
+	dspace, err := CreateSimpleDataspace([]uint{uint(len(data))}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dspace.Close()
+	dset, err := f.CreateDatasetWith("filtered", T_NATIVE_DOUBLE, dspace, dcpl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dset.Close()
+	if err := dset.Write(&data); err != nil {
+		t.Fatal(err)
+	}
+	got := make([]float64, len(data))
+	if err := dset.Read(&got); err != nil {
+		t.Fatal(err)
+	}
+	for i := range data {
+		if got[i] != data[i] {
+			t.Fatalf("invalid value at %d: got=%v, want=%v", i, got[i], data[i])
+		}
+	}
+
+	if err := dcpl.RemoveFilter(Z_FILTER_FLETCHER32); err != nil {
+		t.Fatalf("RemoveFilter failed: %s", err)
+	}
+	if n, err := dcpl.NFilters(); err != nil || n != 2 {
+		t.Fatalf("NFilters after RemoveFilter: got (%d, %v), want (2, nil)", n, err)
+	}
+	if err := dcpl.RemoveFilter(Z_FILTER_ALL); err != nil {
+		t.Fatalf("RemoveFilter(Z_FILTER_ALL) failed: %s", err)
+	}
+	if n, err := dcpl.NFilters(); err != nil || n != 0 {
+		t.Fatalf("NFilters after removing all: got (%d, %v), want (0, nil)", n, err)
+	}
+}
@@ -21,25 +21,16 @@ func init() {
 	}
 }
 
-// hdferror wraps hdf5 int-based error codes
-type h5error struct {
-	code int
-}
-
-func (h h5error) Error() string {
-	return fmt.Sprintf("code %d", h.code)
-}
-
 func h5err(herr C.herr_t) error {
 	if herr < 0 {
-		return h5error{code: int(herr)}
+		return newError(int(herr))
 	}
 	return nil
 }
 
 func checkID(hid C.hid_t) error {
 	if hid < 0 {
-		return h5error{code: int(hid)}
+		return newError(int(hid))
 	}
 	return nil
 }
@@ -47,6 +38,9 @@ func checkID(hid C.hid_t) error {
 // Close flushes all data to disk, closes all open identifiers, and cleans up memory.
 // It should generally be called before your application exits.
 func Close() error {
+	h5lock()
+	defer h5unlock()
+
 	return h5err(C.H5close())
 }
 
@@ -63,6 +57,9 @@ func (v Version) String() string {
 
 // LibVersion returns version information for the HDF5 library.
 func LibVersion() (Version, error) {
+	h5lock()
+	defer h5unlock()
+
 	var maj, min, rel C.uint
 	var v Version
 	err := h5err(C.H5get_libversion(&maj, &min, &rel))
@@ -76,12 +73,24 @@ func LibVersion() (Version, error) {
 
 // GarbageCollect collects garbage on all free-lists of all types.
 func GarbageCollect() error {
+	h5lock()
+	defer h5unlock()
+
 	return h5err(C.H5garbage_collect())
 }
 
+// IndexType is the type of index used to iterate over links or attributes.
+type IndexType C.H5_index_t
+
+const (
+	INDEX_NAME      IndexType = C.H5_INDEX_NAME      // Index on names
+	INDEX_CRT_ORDER IndexType = C.H5_INDEX_CRT_ORDER // Index on creation order
+)
+
 // Object represents an hdf5 object.
 type Object interface {
 	Name() string
-	Id() int
+	ID() int64
 	File() *File
+	Close() error
 }
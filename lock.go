@@ -0,0 +1,116 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hdf5
+
+// #include "hdf5.h"
+// #include <stdint.h>
+// #ifdef _WIN32
+// #include <windows.h>
+// #else
+// #include <pthread.h>
+// #endif
+//
+// static inline int _go_hdf5_is_threadsafe(void) {
+// #if H5_VERSION_GE(1,10,1)
+//   hbool_t ts = 0;
+//   if (H5is_library_threadsafe(&ts) < 0) {
+//     return 0;
+//   }
+//   return ts ? 1 : 0;
+// #elif defined(H5_HAVE_THREADSAFE)
+//   return 1;
+// #else
+//   return 0;
+// #endif
+// }
+//
+// static inline uint64_t _go_hdf5_thread_id(void) {
+// #ifdef _WIN32
+//   return (uint64_t)GetCurrentThreadId();
+// #else
+//   return (uint64_t)(uintptr_t)pthread_self();
+// #endif
+// }
+import "C"
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// threadSafe is whether the HDF5 library was built with thread-safety
+// support. Calls to the library are serialized by the package otherwise.
+var threadSafe = C._go_hdf5_is_threadsafe() != 0
+
+// IsThreadSafe returns whether the HDF5 library was built with thread-safety
+// support.
+//
+// When it was not, the package serializes its calls to the library with a
+// global lock, so that the package can be used from several goroutines.
+// The lock is held during the iterations of the library, such as the ones
+// of Visit or IterateAttributes, and is taken again by the functions of the
+// package called back from them. Callback functions must not wait for
+// goroutines using the package, as this would deadlock.
+// Calls to the library made outside of the package, by cgo code, are not
+// serialized.
+func IsThreadSafe() bool {
+	return threadSafe
+}
+
+// h5mu is the global lock of the library, used when it is not thread-safe.
+var h5mu recursiveMutex
+
+// h5lock acquires the global lock of the library if it is not thread-safe.
+// It is taken by the exported functions and methods of the package calling
+// into the library; unexported helpers expect their caller to hold it.
+// The calling goroutine is locked to its OS thread until the matching call
+// to h5unlock, even when the library is thread-safe, since the error stack
+// of HDF5 is per thread: a failed call and the walk of its error stack by
+// newError must run on the same thread. This also lets the lock be taken
+// again by the functions called back from the library on that thread.
+func h5lock() {
+	runtime.LockOSThread()
+	if threadSafe {
+		return
+	}
+	h5mu.lock(uint64(C._go_hdf5_thread_id()))
+}
+
+// h5unlock releases the global lock acquired by h5lock.
+func h5unlock() {
+	if !threadSafe {
+		h5mu.unlock()
+	}
+	runtime.UnlockOSThread()
+}
+
+// recursiveMutex is a mutex that may be locked again by the OS thread
+// holding it.
+type recursiveMutex struct {
+	mu    sync.Mutex
+	owner uint64 // identifier of the thread holding mu, or zero
+	depth int    // number of times mu was locked by its owner
+}
+
+func (m *recursiveMutex) lock(thread uint64) {
+	// Only the owner of mu can observe its own identifier in owner.
+	if atomic.LoadUint64(&m.owner) == thread {
+		m.depth++
+		return
+	}
+	m.mu.Lock()
+	atomic.StoreUint64(&m.owner, thread)
+	m.depth = 1
+}
+
+func (m *recursiveMutex) unlock() {
+	m.depth--
+	if m.depth > 0 {
+		return
+	}
+	atomic.StoreUint64(&m.owner, 0)
+	m.mu.Unlock()
+}
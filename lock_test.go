@@ -0,0 +1,118 @@
+// Copyright ©2026 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hdf5
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestRecursiveMutex(t *testing.T) {
+	var m recursiveMutex
+	m.lock(1)
+	m.lock(1)
+
+	locked := make(chan struct{})
+	go func() {
+		m.lock(2)
+		close(locked)
+		m.unlock()
+	}()
+
+	m.unlock()
+	select {
+	case <-locked:
+		t.Fatalf("mutex acquired by another thread while still held")
+	default:
+	}
+	m.unlock()
+	<-locked
+}
+
+func TestConcurrentAccess(t *testing.T) {
+	t.Logf("thread-safe HDF5 library: %v", IsThreadSafe())
+
+	const (
+		fname    = "concurrent.h5"
+		workers  = 16
+		attempts = 20
+	)
+
+	f, err := CreateFile(fname, F_ACC_TRUNC)
+	if err != nil {
+		t.Fatalf("CreateFile failed: %s", err)
+	}
+	defer os.Remove(fname)
+	defer f.Close()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			if err := hammer(f, w, attempts); err != nil {
+				errs <- fmt.Errorf("worker %d: %w", w, err)
+			}
+		}(w)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+// hammer writes and reads back the dataset and the attributes of worker w.
+func hammer(f *File, w, attempts int) error {
+	name := fmt.Sprintf("data%d", w)
+	want := make([]float64, 64)
+	for i := range want {
+		want[i] = float64(w*len(want) + i)
+	}
+	if err := f.MakeDataset(name, []uint{8, 8}, want); err != nil {
+		return err
+	}
+
+	for i := 0; i < attempts; i++ {
+		got := make([]float64, len(want))
+		if err := f.ReadDataset(name, got); err != nil {
+			return err
+		}
+		if !reflect.DeepEqual(got, want) {
+			return fmt.Errorf("data differs: got %v, want %v", got, want)
+		}
+		if err := f.SetAttribute(name, fmt.Sprintf("attempt%d", i), []int32{int32(i)}); err != nil {
+			return err
+		}
+	}
+
+	ds, err := f.OpenDataset(name)
+	if err != nil {
+		return err
+	}
+	defer ds.Close()
+
+	// Calls to the package from callbacks must not deadlock.
+	var n int
+	err = ds.IterateAttributes(func(name string, _ AttributeInfo) error {
+		attr, err := ds.OpenAttribute(name)
+		if err != nil {
+			return err
+		}
+		n++
+		return attr.Close()
+	})
+	if err != nil {
+		return err
+	}
+	if n != attempts {
+		return fmt.Errorf("unexpected number of attributes: got %d, want %d", n, attempts)
+	}
+	return nil
+}